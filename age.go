@@ -0,0 +1,121 @@
+package thl
+
+import (
+	"time"
+)
+
+/*******************
+ *** Age Helpers ***
+ *******************/
+
+// LeapDayRule decides on which day a February 29 birthday or anniversary
+// falls in years without a February 29
+type LeapDayRule int
+
+const (
+	// LeapDayOnFebruary28 moves the anniversary to the last day of February
+	LeapDayOnFebruary28 LeapDayRule = iota
+	// LeapDayOnMarch1 moves the anniversary to the day after February 28, like AddYears does
+	LeapDayOnMarch1
+)
+
+// Returns the midnight in UTC of the wall-clock calendar day of the date
+func calendarDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Returns the calendar day in the month, clamping the day to the last day of
+// the month. The month may overflow into the following years.
+func calendarDateClamped(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	if daysInMonth := GetDaysInMonth(first); day > daysInMonth {
+		day = daysInMonth
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+// Returns the anniversary of the date in the year, keeping its time of day and location
+func anniversaryIn(date time.Time, year int, rule LeapDayRule) time.Time {
+	if date.Month() == time.February && date.Day() == 29 && !IsLeapYear(year) && rule == LeapDayOnFebruary28 {
+		return time.Date(year,
+			time.February,
+			28,
+			date.Hour(),
+			date.Minute(),
+			date.Second(),
+			date.Nanosecond(),
+			date.Location())
+	}
+	return AddYears(date, year-date.Year())
+}
+
+// Age returns the completed years from the birth up to the calendar day of
+// the at date. It is 0 when the at date is before the birth.
+func Age(birth, at time.Time, rule LeapDayRule) int {
+	years := at.Year() - birth.Year()
+	if calendarDate(at).Before(calendarDate(anniversaryIn(birth, at.Year(), rule))) {
+		years--
+	}
+
+	if years < 0 {
+		return 0
+	}
+	return years
+}
+
+// AgeBreakdown returns the age as completed years, completed months after
+// the last birthday and days after the last completed month. Months end on
+// the day of the month of the birth, or on the last day of shorter months.
+func AgeBreakdown(birth, at time.Time, rule LeapDayRule) (years, months, days int) {
+	target := calendarDate(at)
+	if target.Before(calendarDate(birth)) {
+		return 0, 0, 0
+	}
+
+	years = Age(birth, at, rule)
+	anniversary := calendarDate(anniversaryIn(birth, birth.Year()+years, rule))
+
+	// a February 29 anniversary moved to March 1 counts its months from the 1st
+	day := birth.Day()
+	if anniversary.Month() != birth.Month() {
+		day = anniversary.Day()
+	}
+
+	monthEnd := anniversary
+	for {
+		next := calendarDateClamped(anniversary.Year(), anniversary.Month()+time.Month(months+1), day)
+		if next.After(target) {
+			break
+		}
+		months++
+		monthEnd = next
+	}
+
+	days = int(target.Sub(monthEnd) / (24 * time.Hour))
+	return years, months, days
+}
+
+// NextAnniversary returns the first anniversary of the date that is after the
+// passed instant. The anniversary keeps the time of day and location of the date.
+func NextAnniversary(date, after time.Time, rule LeapDayRule) time.Time {
+	year := after.Year()
+	if year <= date.Year() {
+		year = date.Year() + 1
+	}
+
+	anniversary := anniversaryIn(date, year, rule)
+	for !anniversary.After(after) {
+		year++
+		anniversary = anniversaryIn(date, year, rule)
+	}
+	return anniversary
+}
+
+// IsBirthday reports whether the calendar day of the date is a birthday,
+// the day of the birth itself included
+func IsBirthday(birth, date time.Time, rule LeapDayRule) bool {
+	if calendarDate(date).Before(calendarDate(birth)) {
+		return false
+	}
+	return calendarDate(date).Equal(calendarDate(anniversaryIn(birth, date.Year(), rule)))
+}
@@ -0,0 +1,80 @@
+package thl
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+/************************
+ *** Boundary Tickers ***
+ ************************/
+
+// Longest wait between two readings of the wall clock. Timers run on the
+// monotonic clock, which stops while the system sleeps, so waking up
+// regularly notices a boundary that passed during the sleep.
+const maxBoundaryWait = time.Minute
+
+// Returns the first boundary of the unit after the date, weeks starting on
+// Sunday as with StartOfWeekOn(date, time.Sunday). Starting the unit again
+// keeps days at midnight after a daylight saving change.
+func nextBoundary(date time.Time, unit Unit) time.Time {
+	return startOfUnit(addUnits(startOfUnit(date, unit, time.Sunday), unit, 1), unit, time.Sunday)
+}
+
+// Sends every boundary of the unit in the location, each after its jitter.
+// Boundaries missed while the system slept are sent once, as the latest of them.
+func everyBoundary(ctx context.Context, unit Unit, loc *time.Location, clock Clock, jitter func() time.Duration) <-chan time.Time {
+	boundaries := make(chan time.Time)
+	go func() {
+		defer close(boundaries)
+		boundary := nextBoundary(clock.Now().In(loc), unit)
+		for {
+			target := boundary.Add(jitter())
+			for now := clock.Now(); now.Before(target); now = clock.Now() {
+				wait := target.Sub(now)
+				if wait > maxBoundaryWait {
+					wait = maxBoundaryWait
+				}
+				select {
+				case <-clock.After(wait):
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if latest := startOfUnit(clock.Now().In(loc), unit, time.Sunday); latest.After(boundary) {
+				boundary = latest
+			}
+			select {
+			case boundaries <- boundary:
+			case <-ctx.Done():
+				return
+			}
+			boundary = nextBoundary(boundary, unit)
+		}
+	}()
+	return boundaries
+}
+
+// EveryBoundary sends the start of every unit in the location, e.g. every
+// midnight for UnitDay, aligned to the wall clock instead of drifting like
+// time.Ticker. Weeks start on Sunday, as with StartOfWeekOn(date, time.Sunday).
+// Boundaries follow daylight saving changes, and the ones missed while the
+// system slept are sent once, as the latest of them. The channel is closed
+// when the context is done.
+func EveryBoundary(ctx context.Context, unit Unit, loc *time.Location) <-chan time.Time {
+	return everyBoundary(ctx, unit, loc, SystemClock, func() time.Duration { return 0 })
+}
+
+// EveryBoundaryWithJitter works like EveryBoundary but sends each boundary
+// after a random delay below maxJitter, so a fleet of machines does not
+// fire at the same instant. The sent times are still the boundaries.
+func EveryBoundaryWithJitter(ctx context.Context, unit Unit, loc *time.Location, maxJitter time.Duration) <-chan time.Time {
+	return everyBoundary(ctx, unit, loc, SystemClock, func() time.Duration {
+		if maxJitter <= 0 {
+			return 0
+		}
+		return time.Duration(rand.Int63n(int64(maxJitter)))
+	})
+}
@@ -0,0 +1,112 @@
+package thl
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// sleepyClock sleeps once through extra time on the first wait
+type sleepyClock struct {
+	manualClock
+	sleep time.Duration
+}
+
+func (c *sleepyClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(c.sleep)
+	c.sleep = 0
+	return c.manualClock.After(d)
+}
+
+func takeBoundaries(t *testing.T, boundaries <-chan time.Time, count int) []string {
+	var taken []string
+	for boundary := range boundaries {
+		taken = append(taken, boundary.Format("2006-01-02 15:04 MST"))
+		if len(taken) == count {
+			return taken
+		}
+	}
+	t.Fatalf("channel closed after %v", taken)
+	return nil
+}
+
+func expectBoundaries(t *testing.T, found, expected []string) {
+	t.Helper()
+	for i := range expected {
+		if found[i] != expected[i] {
+			t.Fatalf("found %v, expected %v", found, expected)
+		}
+	}
+}
+
+func noJitter() time.Duration {
+	return 0
+}
+
+func TestEveryBoundaryAcrossDaylightSavingChanges(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &manualClock{now: time.Date(2024, 3, 10, 0, 30, 0, 0, newYork)}
+	expectBoundaries(t, takeBoundaries(t, everyBoundary(ctx, UnitHour, newYork, clock, noJitter), 3),
+		[]string{"2024-03-10 01:00 EST", "2024-03-10 03:00 EDT", "2024-03-10 04:00 EDT"})
+
+	clock = &manualClock{now: time.Date(2024, 11, 3, 0, 30, 0, 0, newYork)}
+	expectBoundaries(t, takeBoundaries(t, everyBoundary(ctx, UnitHour, newYork, clock, noJitter), 3),
+		[]string{"2024-11-03 01:00 EDT", "2024-11-03 01:00 EST", "2024-11-03 02:00 EST"})
+
+	clock = &manualClock{now: time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)}
+	expectBoundaries(t, takeBoundaries(t, everyBoundary(ctx, UnitDay, newYork, clock, noJitter), 3),
+		[]string{"2024-03-10 00:00 EST", "2024-03-11 00:00 EDT", "2024-03-12 00:00 EDT"})
+}
+
+func TestEveryBoundaryOfWeeksAndMonths(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &manualClock{now: time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)}
+	expectBoundaries(t, takeBoundaries(t, everyBoundary(ctx, UnitMonth, time.UTC, clock, noJitter), 2),
+		[]string{"2024-02-01 00:00 UTC", "2024-03-01 00:00 UTC"})
+
+	clock = &manualClock{now: time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)}
+	expectBoundaries(t, takeBoundaries(t, everyBoundary(ctx, UnitWeek, time.UTC, clock, noJitter), 2),
+		[]string{"2024-02-04 00:00 UTC", "2024-02-11 00:00 UTC"})
+}
+
+func TestEveryBoundaryAfterSystemSleep(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &sleepyClock{manualClock: manualClock{now: time.Date(2024, 1, 1, 9, 59, 0, 0, time.UTC)}, sleep: 3*time.Hour + 30*time.Minute}
+	expectBoundaries(t, takeBoundaries(t, everyBoundary(ctx, UnitHour, time.UTC, clock, noJitter), 2),
+		[]string{"2024-01-01 13:00 UTC", "2024-01-01 14:00 UTC"})
+}
+
+func TestEveryBoundaryWithJitterSendsBoundaries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &manualClock{now: time.Date(2024, 1, 1, 9, 59, 0, 0, time.UTC)}
+	jitter := func() time.Duration { return 20 * time.Second }
+	boundaries := everyBoundary(ctx, UnitMinute, time.UTC, clock, jitter)
+	for _, expected := range []time.Time{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC)} {
+		boundary := <-boundaries
+		if !boundary.Equal(expected) {
+			t.Fatalf("found %v, expected %v", boundary, expected)
+		}
+	}
+
+	cancel()
+	for range boundaries {
+	}
+}
+
+func TestEveryBoundaryStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	boundaries := EveryBoundaryWithJitter(ctx, UnitYear, time.UTC, time.Second)
+	cancel()
+	if _, open := <-boundaries; open {
+		t.Fatal("expected the channel to be closed")
+	}
+}
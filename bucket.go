@@ -0,0 +1,86 @@
+package thl
+
+import (
+	"sort"
+	"time"
+)
+
+/*************************
+ *** Bucketing Helpers ***
+ *************************/
+
+// BucketOptions configures how dates are grouped into buckets
+type BucketOptions struct {
+	// Location whose calendar the buckets follow. Nil means the location of the
+	// date passed to Bucket, or of the earliest item passed to GroupBy.
+	Location *time.Location
+	// WeekStart is the first day of week buckets
+	WeekStart time.Weekday
+	// From and To widen the range GroupBy returns buckets for beyond the dates
+	// of the items. Zero dates are ignored.
+	From time.Time
+	To   time.Time
+}
+
+// TimeBucket is a bucket GroupBy returns with the items falling into it
+type TimeBucket[T any] struct {
+	Range TimeRange
+	Items []T
+}
+
+// Returns the bucket of the unit the date falls into, the date being already in the bucket location
+func bucketOf(date time.Time, unit Unit, weekStart time.Weekday) TimeRange {
+	start := startOfUnit(date, unit, weekStart)
+	return TimeRange{Start: start, End: startOfUnit(addUnits(start, unit, 1), unit, weekStart)}
+}
+
+// Bucket returns the range of the unit the date falls into, e.g. the hour or the week
+func Bucket(date time.Time, unit Unit, opts BucketOptions) TimeRange {
+	if opts.Location != nil {
+		date = date.In(opts.Location)
+	}
+	return bucketOf(date, unit, opts.WeekStart)
+}
+
+// GroupBy sorts the items into consecutive buckets of the unit by the date the
+// key function returns. Buckets in between the earliest and latest item are
+// returned even when they are empty, items keep their order within a bucket.
+func GroupBy[T any](items []T, key func(T) time.Time, unit Unit, opts BucketOptions) []TimeBucket[T] {
+	var dates []time.Time
+	for _, item := range items {
+		dates = append(dates, key(item))
+	}
+
+	bounds := append([]time.Time{}, dates...)
+	for _, bound := range []time.Time{opts.From, opts.To} {
+		if !bound.IsZero() {
+			bounds = append(bounds, bound)
+		}
+	}
+
+	earliest, err := Min(bounds)
+	if err != nil {
+		return nil
+	}
+	latest, _ := Max(bounds)
+
+	loc := opts.Location
+	if loc == nil {
+		loc = earliest.Location()
+	}
+
+	var buckets []TimeBucket[T]
+	last := bucketOf(latest.In(loc), unit, opts.WeekStart)
+	for current := bucketOf(earliest.In(loc), unit, opts.WeekStart); !current.Start.After(last.Start); current = bucketOf(current.End, unit, opts.WeekStart) {
+		buckets = append(buckets, TimeBucket[T]{Range: current})
+	}
+
+	for i, date := range dates {
+		index := sort.Search(len(buckets), func(j int) bool {
+			return date.Before(buckets[j].Range.End)
+		})
+		buckets[index].Items = append(buckets[index].Items, items[i])
+	}
+
+	return buckets
+}
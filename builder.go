@@ -0,0 +1,121 @@
+package thl
+
+import (
+	"time"
+)
+
+/**************************
+ *** Validation Helpers ***
+ **************************/
+
+// Returns a RangeError for the first field of the date that is out of range
+func validateDate(year int, month time.Month, day int) error {
+	if err := checkRange("month", int(month), 1, 12); err != nil {
+		return err
+	}
+	daysInMonth := GetDaysInMonth(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC))
+	return checkRange("day of month", day, 1, daysInMonth)
+}
+
+// Returns a RangeError for the first field of the time of day that is out of range
+func validateTime(hour, minute, second, nanosecond int) error {
+	if err := checkRange("hour", hour, 0, 23); err != nil {
+		return err
+	}
+	if err := checkRange("minute", minute, 0, 59); err != nil {
+		return err
+	}
+	if err := checkRange("second", second, 0, 59); err != nil {
+		return err
+	}
+	return checkRange("nanosecond", nanosecond, 0, 999999999)
+}
+
+// IsValidDate reports whether the day exists in the month of the year,
+// unlike time.Date which normalizes 2017-02-29 to 2017-03-01
+func IsValidDate(year int, month time.Month, day int) bool {
+	return validateDate(year, month, day) == nil
+}
+
+// IsValidTime reports whether the values form a wall-clock time of day
+func IsValidTime(hour, minute, second, nanosecond int) bool {
+	return validateTime(hour, minute, second, nanosecond) == nil
+}
+
+/***********************
+ *** Builder Helpers ***
+ ***********************/
+
+// Builder assembles a date field by field and, unlike time.Date, refuses
+// values out of range instead of normalizing them. Fields that are not set
+// default to the first instant of year 1 in UTC.
+type Builder struct {
+	year       int
+	month      time.Month
+	day        int
+	hour       int
+	minute     int
+	second     int
+	nanosecond int
+	loc        *time.Location
+}
+
+// New starts building a date
+func New() *Builder {
+	return &Builder{year: 1, month: time.January, day: 1, loc: time.UTC}
+}
+
+func (b *Builder) Year(year int) *Builder {
+	b.year = year
+	return b
+}
+
+func (b *Builder) Month(month time.Month) *Builder {
+	b.month = month
+	return b
+}
+
+func (b *Builder) Day(day int) *Builder {
+	b.day = day
+	return b
+}
+
+func (b *Builder) Hour(hour int) *Builder {
+	b.hour = hour
+	return b
+}
+
+func (b *Builder) Minute(minute int) *Builder {
+	b.minute = minute
+	return b
+}
+
+func (b *Builder) Second(second int) *Builder {
+	b.second = second
+	return b
+}
+
+func (b *Builder) Nanosecond(nanosecond int) *Builder {
+	b.nanosecond = nanosecond
+	return b
+}
+
+// Location sets the location of the date, nil meaning UTC
+func (b *Builder) Location(loc *time.Location) *Builder {
+	if loc == nil {
+		loc = time.UTC
+	}
+	b.loc = loc
+	return b
+}
+
+// Build returns the date or a RangeError naming the first field out of range
+func (b *Builder) Build() (time.Time, error) {
+	if err := validateDate(b.year, b.month, b.day); err != nil {
+		return time.Time{}, err
+	}
+	if err := validateTime(b.hour, b.minute, b.second, b.nanosecond); err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(b.year, b.month, b.day, b.hour, b.minute, b.second, b.nanosecond, b.loc), nil
+}
@@ -0,0 +1,129 @@
+package thl
+
+import (
+	"sort"
+	"time"
+)
+
+/***********************
+ *** Cadence Helpers ***
+ ***********************/
+
+// Gap is a stretch of time without timestamps that is longer than expected
+type Gap struct {
+	// Range runs from the last timestamp before the gap to the first one after it
+	Range TimeRange
+	// Missing is the number of timestamps expected within the gap
+	Missing int
+}
+
+// Returns a chronologically sorted copy of the dates
+func sortedCopy(datesSlice []time.Time) []time.Time {
+	dates := append([]time.Time(nil), datesSlice...)
+	SortAsc(dates)
+	return dates
+}
+
+// Gaps finds where consecutive timestamps are further apart than the expected
+// interval plus the tolerance. The dates do not need to be sorted.
+func Gaps(datesSlice []time.Time, expectedInterval, tolerance time.Duration) []Gap {
+	var gaps []Gap
+	if expectedInterval <= 0 {
+		return gaps
+	}
+
+	dates := sortedCopy(datesSlice)
+	for i := 1; i < len(dates); i++ {
+		interval := dates[i].Sub(dates[i-1])
+		if interval > expectedInterval+tolerance {
+			missing := int((interval+expectedInterval/2)/expectedInterval) - 1
+			if missing < 1 {
+				missing = 1
+			}
+			gaps = append(gaps, Gap{Range: TimeRange{Start: dates[i-1], End: dates[i]}, Missing: missing})
+		}
+	}
+	return gaps
+}
+
+// Irregularities finds consecutive timestamps whose distance differs from the
+// expected interval by more than the tolerance, whether too far apart or too close
+func Irregularities(datesSlice []time.Time, expectedInterval, tolerance time.Duration) []TimeRange {
+	var irregular []TimeRange
+
+	dates := sortedCopy(datesSlice)
+	for i := 1; i < len(dates); i++ {
+		deviation := dates[i].Sub(dates[i-1]) - expectedInterval
+		if deviation > tolerance || -deviation > tolerance {
+			irregular = append(irregular, TimeRange{Start: dates[i-1], End: dates[i]})
+		}
+	}
+	return irregular
+}
+
+// Duplicates returns every instant that occurs more than once, each reported once in chronological order
+func Duplicates(datesSlice []time.Time) []time.Time {
+	var duplicates []time.Time
+
+	dates := sortedCopy(datesSlice)
+	for i := 1; i < len(dates); i++ {
+		if !dates[i].Equal(dates[i-1]) {
+			continue
+		}
+
+		if count := len(duplicates); count == 0 || !duplicates[count-1].Equal(dates[i]) {
+			duplicates = append(duplicates, dates[i])
+		}
+	}
+	return duplicates
+}
+
+// MedianInterval returns the median distance between consecutive timestamps
+func MedianInterval(datesSlice []time.Time) (time.Duration, error) {
+	if datesSlice == nil {
+		return 0, ErrNilSlice
+	}
+
+	if len(datesSlice) < 2 {
+		return 0, ErrTooFewDates
+	}
+
+	dates := sortedCopy(datesSlice)
+	intervals := make([]time.Duration, len(dates)-1)
+	for i := range intervals {
+		intervals[i] = dates[i+1].Sub(dates[i])
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i] < intervals[j]
+	})
+
+	middle := len(intervals) / 2
+	if len(intervals)%2 == 1 {
+		return intervals[middle], nil
+	}
+	return intervals[middle-1] + (intervals[middle]-intervals[middle-1])/2, nil
+}
+
+// DetectCadence guesses whether the timestamps repeat hourly, daily, weekly or
+// monthly from their median interval. Daily and weekly cadences allow for an
+// hour of daylight saving change, monthly ones for months of 28 to 31 days.
+// With an error the unit is UnitUnknown.
+func DetectCadence(datesSlice []time.Time) (Unit, error) {
+	median, err := MedianInterval(datesSlice)
+	if err != nil {
+		return UnitUnknown, err
+	}
+
+	day := 24 * time.Hour
+	switch {
+	case median >= 55*time.Minute && median <= 65*time.Minute:
+		return UnitHour, nil
+	case median >= day-time.Hour && median <= day+time.Hour:
+		return UnitDay, nil
+	case median >= 7*day-time.Hour && median <= 7*day+time.Hour:
+		return UnitWeek, nil
+	case median >= 28*day-time.Hour && median <= 31*day+time.Hour:
+		return UnitMonth, nil
+	}
+	return UnitUnknown, ErrNoCadence
+}
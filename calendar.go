@@ -0,0 +1,409 @@
+package thl
+
+import (
+	"fmt"
+	"time"
+)
+
+/************************
+ *** Calendar Systems ***
+ ************************/
+
+// CalendarDate is a day in a calendar system other than the Gregorian one.
+// Months are numbered from 1 in the order they have in the calendar year.
+type CalendarDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+func (date CalendarDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", date.Year, date.Month, date.Day)
+}
+
+// Calendar converts dates to and from a calendar system and describes its years and months
+type Calendar interface {
+	// FromTime returns the day in the calendar of the wall-clock date
+	FromTime(date time.Time) CalendarDate
+	// ToTime returns the start of the day in the passed location. Days outside
+	// of the month roll over into the following or preceding months.
+	ToTime(date CalendarDate, loc *time.Location) time.Time
+	// DaysInMonth returns the number of days of the month of the year
+	DaysInMonth(year, month int) int
+	// MonthsInYear returns the number of months of the year
+	MonthsInYear(year int) int
+	// IsLeapYear reports whether the year has a leap day or a leap month
+	IsLeapYear(year int) bool
+}
+
+// Returns the floor of the division, also for negative numbers
+func floorDiv(a, b int) int {
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		return a/b - 1
+	}
+	return a / b
+}
+
+// Returns the remainder of the floor division, never negative for a positive divisor
+func floorMod(a, b int) int {
+	return a - floorDiv(a, b)*b
+}
+
+// Returns the start of the day keeping the wall-clock time of the date
+func withTimeOfDay(day, date time.Time) time.Time {
+	return time.Date(day.Year(),
+		day.Month(),
+		day.Day(),
+		date.Hour(),
+		date.Minute(),
+		date.Second(),
+		date.Nanosecond(),
+		date.Location())
+}
+
+// GetDaysInMonthIn returns the number of days of the month the date falls in in the calendar
+func GetDaysInMonthIn(calendar Calendar, date time.Time) int {
+	day := calendar.FromTime(date)
+	return calendar.DaysInMonth(day.Year, day.Month)
+}
+
+// IsLeapYearIn reports whether the date falls in a leap year of the calendar
+func IsLeapYearIn(calendar Calendar, date time.Time) bool {
+	return calendar.IsLeapYear(calendar.FromTime(date).Year)
+}
+
+// StartOfMonthIn returns the start of the first day of the month the date falls in in the calendar
+func StartOfMonthIn(calendar Calendar, date time.Time) time.Time {
+	day := calendar.FromTime(date)
+	day.Day = 1
+	return calendar.ToTime(day, date.Location())
+}
+
+// EndOfMonthIn returns the end of the last day of the month the date falls in in the calendar
+func EndOfMonthIn(calendar Calendar, date time.Time) time.Time {
+	day := calendar.FromTime(date)
+	day.Day = calendar.DaysInMonth(day.Year, day.Month)
+	return EndOfDay(calendar.ToTime(day, date.Location()))
+}
+
+// AddMonthsIn adds the amount of months of the calendar to the date, keeping
+// its wall-clock time. Unlike AddMonths, a day missing from the resulting month
+// is clamped to the last day of the month.
+func AddMonthsIn(calendar Calendar, date time.Time, amount int) time.Time {
+	day := calendar.FromTime(date)
+	for ; amount > 0; amount-- {
+		day.Month++
+		if day.Month > calendar.MonthsInYear(day.Year) {
+			day.Year, day.Month = day.Year+1, 1
+		}
+	}
+	for ; amount < 0; amount++ {
+		day.Month--
+		if day.Month < 1 {
+			day.Year--
+			day.Month = calendar.MonthsInYear(day.Year)
+		}
+	}
+
+	if daysInMonth := calendar.DaysInMonth(day.Year, day.Month); day.Day > daysInMonth {
+		day.Day = daysInMonth
+	}
+	return withTimeOfDay(calendar.ToTime(day, date.Location()), date)
+}
+
+/***********************
+ *** Julian Calendar ***
+ ***********************/
+
+// JulianCalendar is the proleptic Julian calendar, with a leap day every four
+// years. Years before 1 are numbered astronomically, 0 being 1 BC.
+type JulianCalendar struct{}
+
+func (JulianCalendar) IsLeapYear(year int) bool {
+	return floorMod(year, 4) == 0
+}
+
+func (JulianCalendar) MonthsInYear(year int) int {
+	return 12
+}
+
+func (calendar JulianCalendar) DaysInMonth(year, month int) int {
+	if month == 2 && calendar.IsLeapYear(year) {
+		return 29
+	}
+	return GetDaysInMonth(time.Date(2001, time.Month(month), 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (JulianCalendar) FromTime(date time.Time) CalendarDate {
+	c := ToJulianDayNumber(date) + 32082
+	d := floorDiv(4*c+3, 1461)
+	e := c - floorDiv(1461*d, 4)
+	m := floorDiv(5*e+2, 153)
+	return CalendarDate{
+		Year:  d - 4800 + m/10,
+		Month: m + 3 - 12*(m/10),
+		Day:   e - floorDiv(153*m+2, 5) + 1,
+	}
+}
+
+func (JulianCalendar) ToTime(date CalendarDate, loc *time.Location) time.Time {
+	a := floorDiv(14-date.Month, 12)
+	y := date.Year + 4800 - a
+	m := date.Month + 12*a - 3
+	return FromJulianDayNumber(date.Day+floorDiv(153*m+2, 5)+365*y+floorDiv(y, 4)-32083, loc)
+}
+
+/************************
+ *** Islamic Calendar ***
+ ************************/
+
+// Julian Day Number of 1 Muharram 1 in the civil tabular Islamic calendar
+const islamicEpoch = 1948440
+
+// IslamicCalendar is the civil tabular Islamic (Hijri) calendar with leap
+// years 2, 5, 7, 10, 13, 16, 18, 21, 24, 26 and 29 of every 30 year cycle.
+// Calendars based on the sighting of the moon may differ by a day or two.
+type IslamicCalendar struct{}
+
+func (IslamicCalendar) IsLeapYear(year int) bool {
+	return floorMod(14+11*year, 30) < 11
+}
+
+func (IslamicCalendar) MonthsInYear(year int) int {
+	return 12
+}
+
+func (calendar IslamicCalendar) DaysInMonth(year, month int) int {
+	if month%2 == 1 || (month == 12 && calendar.IsLeapYear(year)) {
+		return 30
+	}
+	return 29
+}
+
+// Returns the Julian Day Number of the day
+func (IslamicCalendar) dayNumber(year, month, day int) int {
+	return day + floorDiv(59*(month-1)+1, 2) + (year-1)*354 + floorDiv(3+11*year, 30) + islamicEpoch - 1
+}
+
+func (calendar IslamicCalendar) FromTime(date time.Time) CalendarDate {
+	dayNumber := ToJulianDayNumber(date)
+	year := floorDiv(30*(dayNumber-islamicEpoch)+10646, 10631)
+
+	month := 12
+	for m := 1; m < 12; m++ {
+		if dayNumber < calendar.dayNumber(year, m+1, 1) {
+			month = m
+			break
+		}
+	}
+	return CalendarDate{Year: year, Month: month, Day: dayNumber - calendar.dayNumber(year, month, 1) + 1}
+}
+
+func (calendar IslamicCalendar) ToTime(date CalendarDate, loc *time.Location) time.Time {
+	return FromJulianDayNumber(calendar.dayNumber(date.Year, date.Month, date.Day), loc)
+}
+
+/***********************
+ *** Hebrew Calendar ***
+ ***********************/
+
+// Julian Day Number of 1 Tishri 1 in the Hebrew calendar
+const hebrewEpoch = 347998
+
+// HebrewCalendar is the arithmetic Hebrew calendar. Months are numbered in
+// the order of the civil year starting with Tishri, so in leap years month 6
+// is Adar I and month 7 is Adar II, and Elul is month 12 or 13.
+type HebrewCalendar struct{}
+
+func (HebrewCalendar) IsLeapYear(year int) bool {
+	return floorMod(7*year+1, 19) < 7
+}
+
+func (calendar HebrewCalendar) MonthsInYear(year int) int {
+	if calendar.IsLeapYear(year) {
+		return 13
+	}
+	return 12
+}
+
+// Returns the days from the epoch to the molad of Tishri of the year,
+// postponed when it falls on a Sunday, Wednesday or Friday
+func hebrewElapsedDays(year int) int {
+	monthsElapsed := floorDiv(235*year-234, 19)
+	partsElapsed := 12084 + 13753*monthsElapsed
+	days := 29*monthsElapsed + floorDiv(partsElapsed, 25920)
+	if floorMod(3*(days+1), 7) < 3 {
+		days++
+	}
+	return days
+}
+
+// Returns the Julian Day Number of 1 Tishri of the year
+func hebrewNewYear(year int) int {
+	previous, current, next := hebrewElapsedDays(year-1), hebrewElapsedDays(year), hebrewElapsedDays(year+1)
+
+	// keep the year from being 356 days long, or the previous one 382 days long
+	delay := 0
+	if next-current == 356 {
+		delay = 2
+	} else if current-previous == 382 {
+		delay = 1
+	}
+	return hebrewEpoch + current + delay
+}
+
+func (calendar HebrewCalendar) DaysInMonth(year, month int) int {
+	daysInYear := hebrewNewYear(year+1) - hebrewNewYear(year)
+	leap := calendar.IsLeapYear(year)
+	switch {
+	case month == 2:
+		// Heshvan is long in complete years of 355 or 385 days
+		if daysInYear%10 == 5 {
+			return 30
+		}
+		return 29
+	case month == 3:
+		// Kislev is short in deficient years of 353 or 383 days
+		if daysInYear%10 == 3 {
+			return 29
+		}
+		return 30
+	case leap && month == 6:
+		return 30
+	case leap && month > 6:
+		month--
+	}
+
+	// from Tevet on the months alternate between 29 and 30 days
+	if month%2 == 0 {
+		return 29
+	}
+	return 30
+}
+
+func (calendar HebrewCalendar) FromTime(date time.Time) CalendarDate {
+	dayNumber := ToJulianDayNumber(date)
+
+	// the average Hebrew year is 365.2468 days long
+	year := (dayNumber-hebrewEpoch)*10000/3652468 + 1
+	for hebrewNewYear(year+1) <= dayNumber {
+		year++
+	}
+	for hebrewNewYear(year) > dayNumber {
+		year--
+	}
+
+	day := dayNumber - hebrewNewYear(year) + 1
+	month := 1
+	for day > calendar.DaysInMonth(year, month) {
+		day -= calendar.DaysInMonth(year, month)
+		month++
+	}
+	return CalendarDate{Year: year, Month: month, Day: day}
+}
+
+func (calendar HebrewCalendar) ToTime(date CalendarDate, loc *time.Location) time.Time {
+	dayNumber := hebrewNewYear(date.Year) + date.Day - 1
+	for month := 1; month < date.Month; month++ {
+		dayNumber += calendar.DaysInMonth(date.Year, month)
+	}
+	return FromJulianDayNumber(dayNumber, loc)
+}
+
+/****************************
+ *** Solar Hijri Calendar ***
+ ****************************/
+
+// Years of the Solar Hijri calendar where its 33 year leap cycle is broken
+var solarHijriBreaks = []int{-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210,
+	1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178}
+
+// SolarHijriCalendar is the Solar Hijri (Persian) calendar, whose year starts
+// at the March equinox. It follows the arithmetic of Borkowski, which agrees
+// with the astronomical calendar from year -61 up to 3177.
+type SolarHijriCalendar struct{}
+
+// Returns the Gregorian year the Solar Hijri year starts in, the day of March
+// it starts on and the number of years since the last leap year
+func solarHijriYear(year int) (gregorianYear, marchDay, sinceLeap int) {
+	gregorianYear = year + 621
+	leapCount := -14
+	previousBreak := solarHijriBreaks[0]
+	jump := 0
+	for _, nextBreak := range solarHijriBreaks[1:] {
+		jump = nextBreak - previousBreak
+		if year < nextBreak {
+			break
+		}
+		leapCount += jump/33*8 + jump%33/4
+		previousBreak = nextBreak
+	}
+
+	n := year - previousBreak
+	leapCount += n/33*8 + (n%33+3)/4
+	if jump%33 == 4 && jump-n == 4 {
+		leapCount++
+	}
+
+	gregorianLeapCount := gregorianYear/4 - (gregorianYear/100+1)*3/4 - 150
+	marchDay = 20 + leapCount - gregorianLeapCount
+
+	if jump-n < 6 {
+		n = n - jump + (jump+4)/33*33
+	}
+	sinceLeap = ((n+1)%33 - 1) % 4
+	if sinceLeap == -1 {
+		sinceLeap = 4
+	}
+	return gregorianYear, marchDay, sinceLeap
+}
+
+func (SolarHijriCalendar) IsLeapYear(year int) bool {
+	_, _, sinceLeap := solarHijriYear(year)
+	return sinceLeap == 0
+}
+
+func (SolarHijriCalendar) MonthsInYear(year int) int {
+	return 12
+}
+
+func (calendar SolarHijriCalendar) DaysInMonth(year, month int) int {
+	switch {
+	case month <= 6:
+		return 31
+	case month <= 11:
+		return 30
+	case calendar.IsLeapYear(year):
+		return 30
+	}
+	return 29
+}
+
+// Returns the Julian Day Number of 1 Farvardin of the year
+func solarHijriNewYear(year int) int {
+	gregorianYear, marchDay, _ := solarHijriYear(year)
+	return ToJulianDayNumber(time.Date(gregorianYear, time.March, marchDay, 0, 0, 0, 0, time.UTC))
+}
+
+func (calendar SolarHijriCalendar) FromTime(date time.Time) CalendarDate {
+	dayNumber := ToJulianDayNumber(date)
+	year := date.Year() - 621
+	if dayNumber < solarHijriNewYear(year) {
+		year--
+	}
+
+	day := dayNumber - solarHijriNewYear(year)
+	if day < 186 {
+		return CalendarDate{Year: year, Month: 1 + day/31, Day: day%31 + 1}
+	}
+	day -= 186
+	return CalendarDate{Year: year, Month: 7 + day/30, Day: day%30 + 1}
+}
+
+func (calendar SolarHijriCalendar) ToTime(date CalendarDate, loc *time.Location) time.Time {
+	daysBefore := (date.Month - 1) * 31
+	if date.Month > 7 {
+		daysBefore -= date.Month - 7
+	}
+	return FromJulianDayNumber(solarHijriNewYear(date.Year)+daysBefore+date.Day-1, loc)
+}
@@ -0,0 +1,35 @@
+package thl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarsRoundTrip(t *testing.T) {
+	calendars := []Calendar{JulianCalendar{}, IslamicCalendar{}, HebrewCalendar{}, SolarHijriCalendar{}}
+	for _, calendar := range calendars {
+		previous := calendar.FromTime(time.Date(1799, 12, 31, 0, 0, 0, 0, time.UTC))
+		for date := time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC); date.Year() < 2200; date = date.AddDate(0, 0, 1) {
+			day := calendar.FromTime(date)
+			if back := calendar.ToTime(day, time.UTC); !back.Equal(date) {
+				t.Fatalf("%T: %v became %v and then %v", calendar, date, day, back)
+			}
+
+			if day.Month < 1 || day.Month > calendar.MonthsInYear(day.Year) ||
+				day.Day < 1 || day.Day > calendar.DaysInMonth(day.Year, day.Month) {
+				t.Fatalf("%T: %v became the invalid %v", calendar, date, day)
+			}
+
+			// every day follows the previous one
+			switch {
+			case day.Day == previous.Day+1 && day.Month == previous.Month && day.Year == previous.Year:
+			case day.Day == 1 && previous.Day == calendar.DaysInMonth(previous.Year, previous.Month) &&
+				(day.Month == previous.Month+1 && day.Year == previous.Year ||
+					day.Month == 1 && previous.Month == calendar.MonthsInYear(previous.Year) && day.Year == previous.Year+1):
+			default:
+				t.Fatalf("%T: %v does not follow %v", calendar, day, previous)
+			}
+			previous = day
+		}
+	}
+}
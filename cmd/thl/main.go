@@ -0,0 +1,468 @@
+// Command thl exposes the time helpers of the thl package to shell scripts.
+//
+// Usage:
+//
+//	thl add <date> <amount><unit> [--clamp]
+//	thl diff [--unit days] <date> <date>
+//	thl format <date> <pattern>
+//	thl each day|quarter <start> <end>
+//	thl closest <date> [file]
+//	thl quarter <date>
+//
+// Units for add are ms, s, m, h, d, w, M, Q and y. With --clamp adding months,
+// quarters or years ends on the last day of shorter months instead of
+// overflowing into the next one. diff prints how far the first date is after
+// the second one in milliseconds, seconds, minutes, hours, days, weeks or quarters.
+//
+// Dates are "now", RFC 3339 timestamps, "2006-01-02", "2006-01-02 15:04:05" or
+// anything thl.ParseNatural understands. When the date of add, format or
+// quarter is left out or is "-", dates are read from stdin one per line, and
+// so are the dates of closest when the file is left out or is "-". diff and
+// each only take their dates as arguments.
+//
+// The exit status is 0 on success, 1 when a command fails and 2 on bad usage.
+//
+// The --tz flag sets the zone dates are read and printed in, the local one by
+// default. The --json flag prints every result as a line of JSON.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	thl "github.com/AleksandarAleksandrov/go-thl"
+)
+
+// Internal structure holding what every command needs
+type env struct {
+	loc    *time.Location
+	now    time.Time
+	json   bool
+	stdin  io.Reader
+	stdout io.Writer
+}
+
+var amountPattern = regexp.MustCompile(`^([+-]?\d+)(ms|s|m|h|d|w|M|Q|y)$`)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, time.Now()))
+}
+
+// Runs the command line and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer, now time.Time) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "usage: thl add|diff|format|each|closest|quarter [flags] <args>")
+		return 2
+	}
+
+	command, args := args[0], args[1:]
+	flags := flag.NewFlagSet("thl "+command, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	tz := flags.String("tz", "Local", "zone to read and print dates in")
+	asJSON := flags.Bool("json", false, "print results as JSON")
+	clamp := flags.Bool("clamp", false, "add: clamp to the last day of shorter months")
+	unit := flags.String("unit", "days", "diff: unit of the difference")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return 2
+	}
+
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		fmt.Fprintln(stderr, "thl:", err)
+		return 2
+	}
+
+	e := env{loc: loc, now: now.In(loc), json: *asJSON, stdin: stdin, stdout: stdout}
+	switch command {
+	case "add":
+		err = e.add(positional, *clamp)
+	case "diff":
+		err = e.diff(positional, *unit)
+	case "format":
+		err = e.format(positional)
+	case "each":
+		err = e.each(positional)
+	case "closest":
+		err = e.closest(positional)
+	case "quarter":
+		err = e.quarter(positional)
+	default:
+		fmt.Fprintf(stderr, "thl: unknown command %q\n", command)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, "thl:", err)
+		return 1
+	}
+	return 0
+}
+
+// Parses the flags wherever they are among the arguments and returns the
+// other arguments. Only --name flags are flags, so -1M stays an argument.
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var flagArgs, positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+
+		if !strings.HasPrefix(arg, "--") {
+			positional = append(positional, arg)
+			continue
+		}
+
+		flagArgs = append(flagArgs, arg)
+		name := strings.TrimPrefix(arg, "--")
+		if strings.Contains(name, "=") {
+			continue
+		}
+
+		if f := flags.Lookup(name); f != nil && !isBoolFlag(f) && i+1 < len(args) {
+			i++
+			flagArgs = append(flagArgs, args[i])
+		}
+	}
+	return positional, flags.Parse(flagArgs)
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && boolFlag.IsBoolFlag()
+}
+
+// Reads a date in the zone of the command
+func (e env) parseDate(text string) (time.Time, error) {
+	text = strings.TrimSpace(text)
+	if text == "now" {
+		return e.now, nil
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if date, err := time.ParseInLocation(layout, text, e.loc); err == nil {
+			return date.In(e.loc), nil
+		}
+	}
+
+	natural, err := thl.ParseNatural(text, e.now, thl.NaturalOptions{})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return natural.Start, nil
+}
+
+// Returns the date arguments, read from stdin when the argument is "-"
+func (e env) inputs(arg string) ([]string, error) {
+	if arg != "-" {
+		return []string{arg}, nil
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(e.stdin)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// Returns the arguments with "-" in front when the leading date was left out
+func withDate(args []string, count int) ([]string, error) {
+	if len(args) == count-1 {
+		args = append([]string{"-"}, args...)
+	}
+
+	if len(args) != count {
+		return nil, fmt.Errorf("expected %d arguments, got %d", count, len(args))
+	}
+	return args, nil
+}
+
+// Runs the function for every date of the argument and prints the results
+func (e env) forEachDate(arg string, fn func(date time.Time) (interface{}, error)) error {
+	texts, err := e.inputs(arg)
+	if err != nil {
+		return err
+	}
+
+	for _, text := range texts {
+		date, err := e.parseDate(text)
+		if err != nil {
+			return err
+		}
+
+		result, err := fn(date)
+		if err != nil {
+			return err
+		}
+
+		if err := e.print(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prints a result as text or JSON, dates in the zone of the command
+func (e env) print(result interface{}) error {
+	switch value := result.(type) {
+	case time.Time:
+		result = value.In(e.loc)
+	case []time.Time:
+		dates := make([]time.Time, len(value))
+		for i, date := range value {
+			dates[i] = date.In(e.loc)
+		}
+		result = dates
+	}
+
+	if e.json {
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(e.stdout, string(encoded))
+		return err
+	}
+
+	switch value := result.(type) {
+	case time.Time:
+		_, err := fmt.Fprintln(e.stdout, value.Format(time.RFC3339Nano))
+		return err
+	case []time.Time:
+		for _, date := range value {
+			if _, err := fmt.Fprintln(e.stdout, date.Format(time.RFC3339Nano)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	_, err := fmt.Fprintln(e.stdout, result)
+	return err
+}
+
+// Adds the months keeping to the last day of shorter months
+func addMonthsClamped(date time.Time, months int) time.Time {
+	result := thl.AddMonths(date, months)
+	if result.Day() != date.Day() {
+		// the day overflowed into the next month, go back to the end of the previous one
+		result = result.AddDate(0, 0, -result.Day())
+	}
+	return result
+}
+
+func (e env) add(args []string, clamp bool) error {
+	args, err := withDate(args, 2)
+	if err != nil {
+		return err
+	}
+
+	match := amountPattern.FindStringSubmatch(args[1])
+	if match == nil {
+		return fmt.Errorf("amount %q is not a number followed by ms, s, m, h, d, w, M, Q or y", args[1])
+	}
+	amount, err := strconv.Atoi(match[1])
+	if err != nil {
+		return err
+	}
+
+	return e.forEachDate(args[0], func(date time.Time) (interface{}, error) {
+		switch match[2] {
+		case "ms":
+			return thl.AddMilliseconds(date, amount), nil
+		case "s":
+			return thl.AddSeconds(date, amount), nil
+		case "m":
+			return thl.AddMinutes(date, amount), nil
+		case "h":
+			return thl.AddHours(date, amount), nil
+		case "d":
+			return thl.AddDays(date, amount), nil
+		case "w":
+			return thl.AddWeeks(date, amount), nil
+		}
+
+		months := amount
+		switch match[2] {
+		case "Q":
+			months *= 3
+		case "y":
+			months *= 12
+		}
+
+		if clamp {
+			return addMonthsClamped(date, months), nil
+		}
+		return thl.AddMonths(date, months), nil
+	})
+}
+
+func (e env) diff(args []string, unit string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("expected 2 dates, got %d", len(args))
+	}
+
+	dateLeft, err := e.parseDate(args[0])
+	if err != nil {
+		return err
+	}
+	dateRight, err := e.parseDate(args[1])
+	if err != nil {
+		return err
+	}
+
+	var result interface{}
+	switch unit {
+	case "milliseconds", "ms":
+		result = thl.DifferenceInMilliseconds(dateLeft, dateRight)
+	case "seconds", "s":
+		result = thl.DifferenceInSeconds(dateLeft, dateRight)
+	case "minutes", "m":
+		result = thl.DifferenceInMinutes(dateLeft, dateRight)
+	case "hours", "h":
+		result = thl.DifferenceInHours(dateLeft, dateRight)
+	case "days", "d":
+		result = thl.DifferenceInDays(dateLeft, dateRight)
+	case "weeks", "w":
+		result = thl.DifferenceInWeeks(dateLeft, dateRight)
+	case "quarters", "Q":
+		result = thl.DifferenceInQuarters(dateLeft, dateRight)
+	default:
+		return fmt.Errorf("unknown unit %q", unit)
+	}
+	return e.print(result)
+}
+
+func (e env) format(args []string) error {
+	args, err := withDate(args, 2)
+	if err != nil {
+		return err
+	}
+
+	return e.forEachDate(args[0], func(date time.Time) (interface{}, error) {
+		return thl.Format(date, args[1]), nil
+	})
+}
+
+func (e env) each(args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("expected a unit and 2 dates, got %d arguments", len(args))
+	}
+
+	start, err := e.parseDate(args[1])
+	if err != nil {
+		return err
+	}
+	end, err := e.parseDate(args[2])
+	if err != nil {
+		return err
+	}
+
+	var dates []time.Time
+	switch args[0] {
+	case "day":
+		dates, err = thl.EachDay(start, end)
+	case "quarter":
+		dates, err = thl.EachQuarterOfInterval(start, end)
+	default:
+		err = fmt.Errorf("unknown unit %q, expected day or quarter", args[0])
+	}
+
+	if err != nil {
+		return err
+	}
+	return e.print(dates)
+}
+
+func (e env) closest(args []string) error {
+	if len(args) == 1 {
+		args = append(args, "-")
+	}
+
+	if len(args) != 2 {
+		return fmt.Errorf("expected a date and a file, got %d arguments", len(args))
+	}
+
+	date, err := e.parseDate(args[0])
+	if err != nil {
+		return err
+	}
+
+	var texts []string
+	if args[1] == "-" {
+		texts, err = e.inputs("-")
+	} else {
+		texts, err = readLines(args[1])
+	}
+	if err != nil {
+		return err
+	}
+
+	candidates := make([]time.Time, 0, len(texts))
+	for _, text := range texts {
+		candidate, err := e.parseDate(text)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	closest, err := thl.ClosestTo(date, candidates)
+	if errors.Is(err, thl.ErrEmptySlice) {
+		return errors.New("no dates to compare with")
+	}
+	if err != nil {
+		return err
+	}
+	return e.print(closest)
+}
+
+// Returns the non-empty lines of the file
+func readLines(name string) ([]string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return env{stdin: file}.inputs("-")
+}
+
+// Internal structure holding the JSON output of the quarter command
+type quarterResult struct {
+	Quarter int       `json:"quarter"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+func (e env) quarter(args []string) error {
+	args, err := withDate(args, 1)
+	if err != nil {
+		return err
+	}
+
+	return e.forEachDate(args[0], func(date time.Time) (interface{}, error) {
+		if !e.json {
+			return thl.GetQuarter(date), nil
+		}
+		return quarterResult{
+			Quarter: thl.GetQuarter(date),
+			Start:   thl.StartOfQuarter(date).In(e.loc),
+			End:     thl.EndOfQuarter(date).In(e.loc),
+		}, nil
+	})
+}
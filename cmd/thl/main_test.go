@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+var now = time.Date(2024, 5, 5, 10, 30, 0, 0, time.UTC)
+
+// Runs thl and prints its exit status when it is not 0
+func runThl(stdin string, args ...string) {
+	if status := run(args, strings.NewReader(stdin), os.Stdout, os.Stdout, now); status != 0 {
+		fmt.Println("exit status", status)
+	}
+}
+
+func Example_add() {
+	runThl("", "add", "2024-01-31", "1M", "--tz", "UTC")
+	runThl("", "add", "2024-01-31", "1M", "--clamp", "--tz", "UTC")
+	runThl("", "add", "--tz=UTC", "2024-03-31", "-1M", "--clamp")
+	runThl("2024-01-01\n2024-02-01\n", "add", "1w", "--tz", "UTC")
+	// Output:
+	// 2024-03-02T00:00:00Z
+	// 2024-02-29T00:00:00Z
+	// 2024-02-29T00:00:00Z
+	// 2024-01-08T00:00:00Z
+	// 2024-02-08T00:00:00Z
+}
+
+func Example_diff() {
+	runThl("", "diff", "--unit", "days", "2024-05-05", "2024-01-01", "--tz", "UTC")
+	runThl("", "diff", "--unit", "hours", "now", "2024-05-05", "--tz", "UTC")
+	runThl("2024-01-01\n", "diff", "2024-05-05", "--tz", "UTC")
+	runThl("", "diff", "--unit", "fortnights", "now", "2024-05-05", "--tz", "UTC")
+	// Output:
+	// 125
+	// 10.5
+	// thl: expected 2 dates, got 1
+	// exit status 1
+	// thl: unknown unit "fortnights"
+	// exit status 1
+}
+
+func Example_format() {
+	runThl("", "format", "now", "yyyy-MM-dd 'at' HH:mm", "--tz", "UTC")
+	runThl("", "format", "next friday", "EEEE d MMMM", "--tz", "UTC")
+	// Output:
+	// 2024-05-05 at 10:30
+	// Friday 10 May
+}
+
+func Example_each() {
+	runThl("", "each", "quarter", "2024-01-15", "2024-08-01", "--tz", "UTC", "--json")
+	runThl("2024-01-15\n2024-08-01\n", "each", "day", "-", "-", "--tz", "UTC")
+	// Output:
+	// ["2024-01-01T00:00:00Z","2024-04-01T00:00:00Z","2024-07-01T00:00:00Z"]
+	// thl: Passed input is not a date the parser understands: "-"
+	// exit status 1
+}
+
+func Example_closest() {
+	runThl("2024-05-01\n2024-05-07\n2024-06-01\n", "closest", "now", "--tz", "UTC")
+	runThl("", "closest", "now", "--tz", "UTC")
+	// Output:
+	// 2024-05-07T00:00:00Z
+	// thl: no dates to compare with
+	// exit status 1
+}
+
+func Example_usage() {
+	runThl("")
+	runThl("", "quarter", "2024-05-05", "--tz", "Mars/Olympus_Mons")
+	runThl("", "tomorrow")
+	// Output:
+	// usage: thl add|diff|format|each|closest|quarter [flags] <args>
+	// exit status 2
+	// thl: unknown time zone Mars/Olympus_Mons
+	// exit status 2
+	// thl: unknown command "tomorrow"
+	// exit status 2
+}
+
+func Example_quarter() {
+	runThl("", "quarter", "2024-05-05", "--tz", "UTC")
+	runThl("", "quarter", "2024-05-05", "--tz", "UTC", "--json")
+	// Output:
+	// 2
+	// {"quarter":2,"start":"2024-04-01T00:00:00Z","end":"2024-06-30T23:59:59.999999999Z"}
+}
@@ -0,0 +1,99 @@
+package thl
+
+import (
+	"context"
+	"time"
+)
+
+/*************************
+ *** Countdown Helpers ***
+ *************************/
+
+// Clock tells the time and waits for it, so tickers can be driven by a fake
+// clock in tests. SystemClock is the clock of the machine.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock reads and waits for the time of the machine
+var SystemClock Clock = systemClock{}
+
+// Countdown is the time left until a target broken down into whole days,
+// hours, minutes and seconds, days being 24 hours long
+type Countdown struct {
+	Target    time.Time
+	Remaining time.Duration
+	Days      int
+	Hours     int
+	Minutes   int
+	Seconds   int
+}
+
+// Until returns the time left from now until the target. Once the target has
+// passed the countdown is expired and all of its parts are 0.
+func Until(target, now time.Time) Countdown {
+	countdown := Countdown{Target: target}
+	remaining := target.Sub(now)
+	if remaining <= 0 {
+		return countdown
+	}
+
+	countdown.Remaining = remaining
+	seconds := int64(remaining / time.Second)
+	countdown.Days = int(seconds / 86400)
+	countdown.Hours = int(seconds % 86400 / 3600)
+	countdown.Minutes = int(seconds % 3600 / 60)
+	countdown.Seconds = int(seconds % 60)
+	return countdown
+}
+
+// IsExpired checks if the target has been reached
+func (c Countdown) IsExpired() bool {
+	return c.Remaining <= 0
+}
+
+// CountdownTicker sends the countdown to the target right away and then at
+// the start of every second, and once more when the target is reached. The
+// channel is closed after the expired countdown is sent or the context is done.
+func CountdownTicker(ctx context.Context, target time.Time, clock Clock) <-chan Countdown {
+	countdowns := make(chan Countdown)
+	go func() {
+		defer close(countdowns)
+		for {
+			now := clock.Now()
+			countdown := Until(target, now)
+			select {
+			case countdowns <- countdown:
+			case <-ctx.Done():
+				return
+			}
+			if countdown.IsExpired() {
+				return
+			}
+
+			// truncating the instant instead of rebuilding the wall clock with
+			// StartOfSecond keeps the repeated hour of a daylight saving change
+			next := now.Truncate(time.Second).Add(time.Second)
+			if target.Before(next) {
+				next = target
+			}
+			select {
+			case <-clock.After(next.Sub(clock.Now())):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return countdowns
+}
@@ -0,0 +1,32 @@
+package thl
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCountdownTickerInRepeatedHour(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	// 01:30 EST, the second time the wall clock reads 01:30 that night
+	now := time.Date(2016, 11, 6, 6, 30, 0, 500000000, time.UTC).In(newYork)
+	clock := &manualClock{now: now}
+
+	var remaining []time.Duration
+	for countdown := range CountdownTicker(context.Background(), now.Add(3*time.Second), clock) {
+		remaining = append(remaining, countdown.Remaining)
+		if len(remaining) > 5 {
+			t.Fatalf("too many countdowns %v", remaining)
+		}
+	}
+
+	expected := []time.Duration{3 * time.Second, 2500 * time.Millisecond, 1500 * time.Millisecond, 500 * time.Millisecond, 0}
+	if len(remaining) != len(expected) {
+		t.Fatalf("found %v, expected %v", remaining, expected)
+	}
+	for i := range expected {
+		if remaining[i] != expected[i] {
+			t.Fatalf("found %v, expected %v", remaining, expected)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package thl
+
+import (
+	"math"
+	"time"
+)
+
+/********************************
+ *** Epoch and Serial Helpers ***
+ ********************************/
+
+const (
+	secondsInDay = 24 * 60 * 60
+
+	// Julian Date of the Unix epoch
+	julianDateUnixEpoch = 2440587.5
+	// Julian Day Number of the day the Unix epoch falls on
+	julianDayNumberUnixEpoch = 2440588
+	// Julian Date of the Modified Julian Date epoch
+	modifiedJulianDateOffset = 2400000.5
+
+	// seconds between 1900-01-01 (NTP era 0) and the Unix epoch
+	ntpUnixEpochOffset = 2208988800
+
+	secondsInWeek = 7 * secondsInDay
+)
+
+var (
+	excel1900Epoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	excel1904Epoch = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// first real day after the fictitious 1900-02-29 of the Excel 1900 system
+	excel1900LeapBug = time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	gpsEpoch  = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+	unixEpoch = time.Unix(0, 0).UTC()
+)
+
+// Returns the fractional number of days between the epoch and the date without
+// going through time.Duration, which overflows after 292 years
+func daysSinceEpoch(epoch, date time.Time) float64 {
+	seconds := date.Unix() - epoch.Unix()
+	return float64(seconds)/secondsInDay + float64(date.Nanosecond())/(secondsInDay*1e9)
+}
+
+// Returns the instant a fractional number of days after the epoch, rounded to the nearest millisecond
+func epochPlusDays(epoch time.Time, days float64) time.Time {
+	wholeDays := math.Floor(days)
+	millis := math.Round((days - wholeDays) * secondsInDay * 1000)
+	return epoch.AddDate(0, 0, int(wholeDays)).Add(time.Duration(millis) * time.Millisecond)
+}
+
+// Returns the wall-clock time of the date as if it was in UTC
+func wallClockInUTC(date time.Time) time.Time {
+	return InZoneKeepWallClock(date, time.UTC)
+}
+
+// ToExcelSerial1900 converts the wall-clock time of the date to a serial number of
+// the Excel 1900 date system. Like Excel it counts the nonexistent 1900-02-29,
+// so dates from 1900-03-01 onwards are one day further from 1899-12-31 than
+// they really are.
+func ToExcelSerial1900(date time.Time) (float64, error) {
+	wall := wallClockInUTC(date)
+	if wall.Before(excel1900Epoch.AddDate(0, 0, 1)) {
+		return 0, ErrBeforeExcelEpoch
+	}
+
+	serial := daysSinceEpoch(excel1900Epoch, wall)
+	if wall.Before(excel1900LeapBug) {
+		serial--
+	}
+	return serial, nil
+}
+
+// FromExcelSerial1900 converts a serial number of the Excel 1900 date system to a
+// wall-clock time in the passed location. Serial numbers from 60 up to 61 point
+// at the nonexistent 1900-02-29 and are rejected.
+func FromExcelSerial1900(serial float64, loc *time.Location) (time.Time, error) {
+	if serial < 0 {
+		return time.Time{}, ErrNegativeSerial
+	}
+
+	if serial >= 60 && serial < 61 {
+		return time.Time{}, ErrExcelLeapBug
+	}
+
+	if serial < 60 {
+		serial++
+	}
+	return InZoneKeepWallClock(epochPlusDays(excel1900Epoch, serial), loc), nil
+}
+
+// ToExcelSerial1904 converts the wall-clock time of the date to a serial number of the Excel 1904 date system
+func ToExcelSerial1904(date time.Time) (float64, error) {
+	wall := wallClockInUTC(date)
+	if wall.Before(excel1904Epoch) {
+		return 0, ErrBeforeExcelEpoch
+	}
+	return daysSinceEpoch(excel1904Epoch, wall), nil
+}
+
+// FromExcelSerial1904 converts a serial number of the Excel 1904 date system to a wall-clock time in the passed location
+func FromExcelSerial1904(serial float64, loc *time.Location) (time.Time, error) {
+	if serial < 0 {
+		return time.Time{}, ErrNegativeSerial
+	}
+	return InZoneKeepWallClock(epochPlusDays(excel1904Epoch, serial), loc), nil
+}
+
+// ToJulianDate returns the astronomical Julian Date of the instant
+func ToJulianDate(date time.Time) float64 {
+	return daysSinceEpoch(unixEpoch, date) + julianDateUnixEpoch
+}
+
+// FromJulianDate returns the instant of the Julian Date in the passed location,
+// rounded to the nearest millisecond
+func FromJulianDate(julianDate float64, loc *time.Location) time.Time {
+	return epochPlusDays(unixEpoch, julianDate-julianDateUnixEpoch).In(loc)
+}
+
+// ToModifiedJulianDate returns the Modified Julian Date of the instant
+func ToModifiedJulianDate(date time.Time) float64 {
+	return daysSinceEpoch(unixEpoch, date) + julianDateUnixEpoch - modifiedJulianDateOffset
+}
+
+// FromModifiedJulianDate returns the instant of the Modified Julian Date in the
+// passed location, rounded to the nearest millisecond
+func FromModifiedJulianDate(modifiedJulianDate float64, loc *time.Location) time.Time {
+	return FromJulianDate(modifiedJulianDate+modifiedJulianDateOffset, loc)
+}
+
+// ToJulianDayNumber returns the Julian Day Number of the calendar day of the date
+func ToJulianDayNumber(date time.Time) int {
+	day := StartOfDay(wallClockInUTC(date))
+	return int(day.Unix()/secondsInDay) + julianDayNumberUnixEpoch
+}
+
+// FromJulianDayNumber returns the start of the day with the Julian Day Number in the passed location
+func FromJulianDayNumber(dayNumber int, loc *time.Location) time.Time {
+	return time.Date(1970, time.January, 1+dayNumber-julianDayNumberUnixEpoch, 0, 0, 0, 0, loc)
+}
+
+// ToUnixMilli returns the number of milliseconds elapsed since the Unix epoch
+func ToUnixMilli(date time.Time) int64 {
+	return date.UnixMilli()
+}
+
+// FromUnixMilli returns the instant the milliseconds since the Unix epoch point at in the passed location
+func FromUnixMilli(millis int64, loc *time.Location) time.Time {
+	return time.UnixMilli(millis).In(loc)
+}
+
+// ToUnixMicro returns the number of microseconds elapsed since the Unix epoch
+func ToUnixMicro(date time.Time) int64 {
+	return date.UnixMicro()
+}
+
+// FromUnixMicro returns the instant the microseconds since the Unix epoch point at in the passed location
+func FromUnixMicro(micros int64, loc *time.Location) time.Time {
+	return time.UnixMicro(micros).In(loc)
+}
+
+// ToNTPTimestamp returns the 64-bit NTP timestamp of the instant. The upper 32 bits
+// hold the seconds since 1900-01-01 modulo the NTP era, the lower 32 bits the
+// fraction of the second.
+func ToNTPTimestamp(date time.Time) uint64 {
+	seconds := uint64(date.Unix()+ntpUnixEpochOffset) & math.MaxUint32
+	fraction := (uint64(date.Nanosecond())<<32 + 5e8) / 1e9
+	return seconds<<32 | fraction
+}
+
+// FromNTPTimestamp returns the instant of the 64-bit NTP timestamp in the passed
+// location. As recommended by RFC 4330 timestamps with the most significant bit
+// cleared are taken to be in era 1, which covers 2036-02-07 to 2104.
+func FromNTPTimestamp(timestamp uint64, loc *time.Location) time.Time {
+	seconds := int64(timestamp >> 32)
+	fraction := timestamp & math.MaxUint32
+
+	if seconds&(1<<31) == 0 {
+		seconds += 1 << 32
+	}
+
+	nanos := int64((fraction*1e9 + 1<<31) >> 32)
+	return time.Unix(seconds-ntpUnixEpochOffset, nanos).In(loc)
+}
+
+// ToGPSWeekSeconds returns the GPS week number and the time elapsed within that week.
+// The date is taken as a reading of the GPS time scale, no leap second correction
+// is applied.
+func ToGPSWeekSeconds(date time.Time) (int, time.Duration) {
+	seconds := date.Unix() - gpsEpoch.Unix()
+	week := seconds / secondsInWeek
+	if seconds < 0 && seconds%secondsInWeek != 0 {
+		week--
+	}
+	seconds -= week * secondsInWeek
+	return int(week), time.Duration(seconds)*time.Second + time.Duration(date.Nanosecond())
+}
+
+// FromGPSWeekSeconds returns the instant of the GPS week number and time within the week in the passed location
+func FromGPSWeekSeconds(week int, sinceStartOfWeek time.Duration, loc *time.Location) time.Time {
+	return gpsEpoch.AddDate(0, 0, 7*week).Add(sinceStartOfWeek).In(loc)
+}
@@ -0,0 +1,126 @@
+package thl
+
+import (
+	"fmt"
+	"time"
+)
+
+/****************************
+ *** Era and Year Helpers ***
+ ****************************/
+
+// Years between the Gregorian year and the years of the Republic of China and the Thai Buddhist Era
+const (
+	rocYearOffset          = 1911
+	thaiBuddhistYearOffset = 543
+)
+
+// JapaneseEra is an era (gengō) of the Japanese calendar
+type JapaneseEra struct {
+	// Name is the romanized name, e.g. Reiwa
+	Name string
+	// Kanji is the name written in Japanese, e.g. 令和
+	Kanji string
+	// Abbreviation is the first letter of the romanized name, e.g. R
+	Abbreviation string
+	// Start is the first day of the era, at midnight in UTC
+	Start time.Time
+}
+
+// The modern Japanese eras. Meiji was proclaimed on 1868-10-23 and counted
+// from the first day of that lunar year, 1868-01-25 in the Gregorian calendar,
+// which Japan only adopted in 1873.
+var (
+	Meiji  = JapaneseEra{Name: "Meiji", Kanji: "明治", Abbreviation: "M", Start: time.Date(1868, 1, 25, 0, 0, 0, 0, time.UTC)}
+	Taisho = JapaneseEra{Name: "Taisho", Kanji: "大正", Abbreviation: "T", Start: time.Date(1912, 7, 30, 0, 0, 0, 0, time.UTC)}
+	Showa  = JapaneseEra{Name: "Showa", Kanji: "昭和", Abbreviation: "S", Start: time.Date(1926, 12, 25, 0, 0, 0, 0, time.UTC)}
+	Heisei = JapaneseEra{Name: "Heisei", Kanji: "平成", Abbreviation: "H", Start: time.Date(1989, 1, 8, 0, 0, 0, 0, time.UTC)}
+	Reiwa  = JapaneseEra{Name: "Reiwa", Kanji: "令和", Abbreviation: "R", Start: time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)}
+)
+
+// JapaneseEras lists the eras in chronological order
+var JapaneseEras = []JapaneseEra{Meiji, Taisho, Showa, Heisei, Reiwa}
+
+// Returns the index of the era in JapaneseEras, or -1 when it is not there
+func japaneseEraIndex(era JapaneseEra) int {
+	for i, known := range JapaneseEras {
+		if known.Name == era.Name {
+			return i
+		}
+	}
+	return -1
+}
+
+// ToJapaneseEra returns the era the wall-clock date falls in and the year of
+// the era, the first year being 1 (gannen)
+func ToJapaneseEra(date time.Time) (JapaneseEra, int, error) {
+	day := calendarDate(date)
+	for i := len(JapaneseEras) - 1; i >= 0; i-- {
+		era := JapaneseEras[i]
+		if !day.Before(era.Start) {
+			return era, date.Year() - era.Start.Year() + 1, nil
+		}
+	}
+	return JapaneseEra{}, 0, ErrOutsideEra
+}
+
+// FromJapaneseEra returns the start of the day in UTC given as a year of the
+// era, a month and a day. The day has to fall within the era.
+func FromJapaneseEra(era JapaneseEra, year, month, day int) (time.Time, error) {
+	index := japaneseEraIndex(era)
+	if index < 0 {
+		return time.Time{}, ErrOutsideEra
+	}
+
+	gregorianYear := JapaneseEras[index].Start.Year() + year - 1
+	if err := validateDate(gregorianYear, time.Month(month), day); err != nil {
+		return time.Time{}, err
+	}
+
+	date := time.Date(gregorianYear, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Before(JapaneseEras[index].Start) {
+		return time.Time{}, ErrOutsideEra
+	}
+	if index+1 < len(JapaneseEras) && !date.Before(JapaneseEras[index+1].Start) {
+		return time.Time{}, ErrOutsideEra
+	}
+	return date, nil
+}
+
+// ToROCYear returns the year of the Republic of China (Minguo) calendar used in
+// Taiwan. Years before 1912 are zero or negative.
+func ToROCYear(date time.Time) int {
+	return date.Year() - rocYearOffset
+}
+
+// FromROCYear returns the Gregorian year of the year of the Republic of China calendar
+func FromROCYear(year int) int {
+	return year + rocYearOffset
+}
+
+// ToThaiBuddhistYear returns the year of the Thai Buddhist Era
+func ToThaiBuddhistYear(date time.Time) int {
+	return date.Year() + thaiBuddhistYearOffset
+}
+
+// FromThaiBuddhistYear returns the Gregorian year of the year of the Thai Buddhist Era
+func FromThaiBuddhistYear(year int) int {
+	return year - thaiBuddhistYearOffset
+}
+
+// Returns a format token writing a part of the Japanese era, or nothing
+// before the first era
+func japaneseEraToken(part func(era JapaneseEra, year int) string) func(date time.Time) string {
+	return func(date time.Time) string {
+		era, year, err := ToJapaneseEra(date)
+		if err != nil {
+			return ""
+		}
+		return part(era, year)
+	}
+}
+
+// Returns the year of the Japanese era padded to two digits
+func paddedEraYear(era JapaneseEra, year int) string {
+	return fmt.Sprintf("%02d", year)
+}
@@ -0,0 +1,115 @@
+package thl
+
+import (
+	"errors"
+	"fmt"
+)
+
+/**************
+ *** Errors ***
+ **************/
+
+// Errors returned by the package. Compare against them with errors.Is, the
+// returned errors may wrap them with more details.
+var (
+	// ErrNilSlice is returned when a slice of dates is nil
+	ErrNilSlice = errors.New("Passed slice of dates was nil")
+	// ErrEmptySlice is returned when a slice of dates has no elements
+	ErrEmptySlice = errors.New("Passed slice of dates was of size 0")
+	// ErrTooFewDates is returned when a slice of dates needs at least two elements
+	ErrTooFewDates = errors.New("Passed slice of dates has less than 2 dates")
+	// ErrOutOfRange is matched by every RangeError
+	ErrOutOfRange = errors.New("Passed value is out of range")
+	// ErrEndBeforeStart is returned when the end of a range is before its start
+	ErrEndBeforeStart = errors.New("End date can not be before start date")
+	// ErrRangesNotOverlapping is returned when ranges expected to overlap do not
+	ErrRangesNotOverlapping = errors.New("Ranges do not overlap")
+
+	// ErrPercentileOutOfRange is returned for percentiles outside of 0 to 100
+	ErrPercentileOutOfRange = errors.New("Passed percentile was less than 0 or more than 100")
+	// ErrNoCircularMean is returned when the times of day cancel each other out,
+	// like 06:00 and 18:00, and so have no mean
+	ErrNoCircularMean = errors.New("Passed times of day cancel out and have no mean")
+	// ErrNoCadence is returned when no regular cadence can be detected
+	ErrNoCadence = errors.New("Passed dates have no hourly, daily, weekly or monthly cadence")
+
+	// ErrEmptyIndex is returned when querying a TimeIndex without dates
+	ErrEmptyIndex = errors.New("Time index is empty")
+	// ErrNoFloor is returned when a TimeIndex has no date before or equal to the query
+	ErrNoFloor = errors.New("No date in the index is before or equal to the passed date")
+	// ErrNoCeiling is returned when a TimeIndex has no date after or equal to the query
+	ErrNoCeiling = errors.New("No date in the index is after or equal to the passed date")
+
+	// ErrInvalidStep is returned when rounding to steps of less than one unit
+	ErrInvalidStep = errors.New("Passed step was less than 1. Date left unchanged.")
+	// ErrUnknownRoundingMode is returned for rounding modes the package does not define
+	ErrUnknownRoundingMode = errors.New("Passed rounding mode is unknown. Date left unchanged.")
+	// ErrUnknownUnit is returned for units the package does not define
+	ErrUnknownUnit = errors.New("Passed unit is unknown. Date left unchanged.")
+
+	// ErrBeforeExcelEpoch is returned for dates an Excel date system can not represent
+	ErrBeforeExcelEpoch = errors.New("Passed date is before the start of the Excel date system")
+	// ErrNegativeSerial is returned for negative Excel serial numbers
+	ErrNegativeSerial = errors.New("Passed serial number was negative")
+	// ErrExcelLeapBug is returned for the serial number of the nonexistent 1900-02-29
+	ErrExcelLeapBug = errors.New("Passed serial number is the nonexistent 1900-02-29 of the Excel 1900 date system")
+
+	// ErrNoWorkingHours is returned when a working schedule is closed for more than a year
+	ErrNoWorkingHours = errors.New("Working schedule has no working hours within a year")
+	// ErrNegativeDuration is returned when a duration may not be negative
+	ErrNegativeDuration = errors.New("Passed duration was negative")
+
+	// ErrSunAlwaysUp is returned when the sun stays above the altitude of the event all day, like during polar day
+	ErrSunAlwaysUp = errors.New("Sun stays above the altitude of the event all day")
+	// ErrSunAlwaysDown is returned when the sun stays below the altitude of the event all day, like during polar night
+	ErrSunAlwaysDown = errors.New("Sun stays below the altitude of the event all day")
+
+	// ErrOutsideEra is returned for dates outside of the known Japanese eras
+	ErrOutsideEra = errors.New("Passed date is outside of the Japanese era")
+	// ErrUnparsableNatural is returned when ParseNatural does not understand the input
+	ErrUnparsableNatural = errors.New("Passed input is not a date the parser understands")
+
+	// ErrMalformedLeapSecondTable is returned when a leap second table can not be read
+	ErrMalformedLeapSecondTable = errors.New("Passed leap second table is malformed")
+	// ErrMalformedZonedTime is returned when the text of a ZonedTime can not be read
+	ErrMalformedZonedTime = errors.New("Passed zoned time is missing the zone name in square brackets")
+)
+
+// RangeError is returned when a value passed for a date field, like the hour
+// or the day of the month, is outside of the values the field can hold
+type RangeError struct {
+	Field string
+	Value int
+	Min   int
+	Max   int
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("Passed %s %d is out of range %d to %d", e.Field, e.Value, e.Min, e.Max)
+}
+
+// Is makes every RangeError match ErrOutOfRange
+func (e *RangeError) Is(target error) bool {
+	return target == ErrOutOfRange
+}
+
+// Returns a RangeError when the value is outside of min and max
+func checkRange(field string, value, min, max int) error {
+	if value < min || value > max {
+		return &RangeError{Field: field, Value: value, Min: min, Max: max}
+	}
+	return nil
+}
+
+// Checks that the slice has elements
+func validateSlice[T any](slice []T) error {
+	if slice == nil {
+		return ErrNilSlice
+	}
+
+	if len(slice) == 0 {
+		return ErrEmptySlice
+	}
+
+	return nil
+}
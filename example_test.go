@@ -1,7 +1,11 @@
 package thl
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -216,7 +220,7 @@ func ExampleSetMillisecond() {
 	// Output:
 	// 3001-01-01 00:00:00 +0000 UTC
 	// 3001-01-01 00:00:00.999 +0000 UTC <nil>
-	// 3001-01-01 00:00:00 +0000 UTC Passed amount was less than 0 or more than 999. Date left unchanged.
+	// 3001-01-01 00:00:00 +0000 UTC Passed millisecond 1000 is out of range 0 to 999
 }
 
 func ExampleAddSeconds() {
@@ -268,7 +272,7 @@ func ExampleSetSeconds() {
 	fmt.Println(SetSeconds(futureDate, 60))
 	// Output:
 	// 3001-01-01 00:00:33 +0000 UTC <nil>
-	// 3001-01-01 00:00:00 +0000 UTC Passed amount was less than 0 or more than 59. Date left unchanged.
+	// 3001-01-01 00:00:00 +0000 UTC Passed second 60 is out of range 0 to 59
 }
 
 func ExampleStartOfSecond() {
@@ -329,7 +333,7 @@ func ExampleSetMinutes() {
 	// Output:
 	// 3001-01-01 00:00:00 +0000 UTC
 	// 3001-01-01 00:59:00 +0000 UTC <nil>
-	// 3001-01-01 00:00:00 +0000 UTC Passed amount was less than 0 or more than 59. Date left unchanged.
+	// 3001-01-01 00:00:00 +0000 UTC Passed minute 60 is out of range 0 to 59
 }
 
 func ExampleStartOfMinute() {
@@ -389,7 +393,7 @@ func ExampleSetHours() {
 	// Output:
 	// 3001-01-01 00:00:00 +0000 UTC
 	// 3001-01-01 23:00:00 +0000 UTC <nil>
-	// 3001-01-01 00:00:00 +0000 UTC Passed amount was less than 0 or more than 23. Date left unchanged.
+	// 3001-01-01 00:00:00 +0000 UTC Passed hour 24 is out of range 0 to 23
 }
 
 func ExampleStartOfHour() {
@@ -467,3 +471,1219 @@ func ExampleIsSameDay() {
 	// true
 	// false
 }
+
+func ExampleInZoneKeepWallClock() {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	meeting := time.Date(2017, 3, 1, 9, 0, 0, 0, time.UTC)
+	fmt.Println(InZoneKeepWallClock(meeting, berlin))
+	fmt.Println(meeting.In(berlin))
+	// Output:
+	// 2017-03-01 09:00:00 +0100 CET
+	// 2017-03-01 10:00:00 +0100 CET
+}
+
+func ExampleZoneOffsetAt() {
+	newYork, _ := time.LoadLocation("America/New_York")
+	fmt.Println(ZoneOffsetAt(newYork, time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(ZoneOffsetAt(newYork, time.Date(2017, 7, 1, 0, 0, 0, 0, time.UTC)))
+	// Output:
+	// -5h0m0s
+	// -4h0m0s
+}
+
+func ExampleOffsetDifference() {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	newYork, _ := time.LoadLocation("America/New_York")
+	// the US switches to summer time two weeks before Europe
+	fmt.Println(OffsetDifference(berlin, newYork, time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)))
+	fmt.Println(OffsetDifference(berlin, newYork, time.Date(2017, 3, 20, 12, 0, 0, 0, time.UTC)))
+	fmt.Println(OffsetDifference(newYork, berlin, time.Date(2017, 3, 20, 12, 0, 0, 0, time.UTC)))
+	// Output:
+	// 6h0m0s
+	// 5h0m0s
+	// -5h0m0s
+}
+
+func ExampleZonedTime() {
+	zoned, _ := LoadZonedTime(time.Date(2017, 3, 20, 13, 0, 0, 0, time.UTC), "America/New_York")
+	fmt.Println(zoned)
+
+	encoded, _ := json.Marshal(struct{ Start ZonedTime }{zoned})
+	fmt.Println(string(encoded))
+
+	var decoded struct{ Start ZonedTime }
+	fmt.Println(json.Unmarshal(encoded, &decoded))
+	fmt.Println(decoded.Start.ZoneName(), decoded.Start.Equal(zoned))
+
+	var broken ZonedTime
+	fmt.Println(broken.UnmarshalText([]byte("2017-03-20T09:00:00-04:00")))
+	// Output:
+	// 2017-03-20T09:00:00-04:00[America/New_York]
+	// {"Start":"2017-03-20T09:00:00-04:00[America/New_York]"}
+	// <nil>
+	// America/New_York true
+	// Passed zoned time is missing the zone name in square brackets
+}
+
+func ExampleToExcelSerial1900() {
+	fmt.Println(ToExcelSerial1900(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(ToExcelSerial1900(time.Date(1900, 2, 28, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(ToExcelSerial1900(time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(ToExcelSerial1900(time.Date(2017, 1, 1, 18, 0, 0, 0, time.UTC)))
+	fmt.Println(ToExcelSerial1900(time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)))
+	// Output:
+	// 1 <nil>
+	// 59 <nil>
+	// 61 <nil>
+	// 42736.75 <nil>
+	// 0 Passed date is before the start of the Excel date system
+}
+
+func ExampleFromExcelSerial1900() {
+	fmt.Println(FromExcelSerial1900(59, time.UTC))
+	fmt.Println(FromExcelSerial1900(60, time.UTC))
+	fmt.Println(FromExcelSerial1900(61, time.UTC))
+	fmt.Println(FromExcelSerial1900(42736.75, time.UTC))
+	fmt.Println(FromExcelSerial1900(-1, time.UTC))
+	// Output:
+	// 1900-02-28 00:00:00 +0000 UTC <nil>
+	// 0001-01-01 00:00:00 +0000 UTC Passed serial number is the nonexistent 1900-02-29 of the Excel 1900 date system
+	// 1900-03-01 00:00:00 +0000 UTC <nil>
+	// 2017-01-01 18:00:00 +0000 UTC <nil>
+	// 0001-01-01 00:00:00 +0000 UTC Passed serial number was negative
+}
+
+func ExampleToExcelSerial1904() {
+	fmt.Println(ToExcelSerial1904(time.Date(2017, 1, 1, 18, 0, 0, 0, time.UTC)))
+	fmt.Println(FromExcelSerial1904(41274.75, time.UTC))
+	// Output:
+	// 41274.75 <nil>
+	// 2017-01-01 18:00:00 +0000 UTC <nil>
+}
+
+func ExampleToJulianDate() {
+	fmt.Println(ToJulianDate(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)))
+	fmt.Println(FromJulianDate(2451545.25, time.UTC))
+	fmt.Println(ToModifiedJulianDate(time.Date(1858, 11, 17, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(FromModifiedJulianDate(57754.5, time.UTC))
+	// Output:
+	// 2.451545e+06
+	// 2000-01-01 18:00:00 +0000 UTC
+	// 0
+	// 2017-01-01 12:00:00 +0000 UTC
+}
+
+func ExampleToJulianDayNumber() {
+	fmt.Println(ToJulianDayNumber(time.Date(2000, 1, 1, 23, 0, 0, 0, time.UTC)))
+	fmt.Println(ToJulianDayNumber(time.Date(-4713, 11, 24, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(FromJulianDayNumber(2457755, time.UTC))
+	// Output:
+	// 2451545
+	// 0
+	// 2017-01-01 00:00:00 +0000 UTC
+}
+
+func ExampleToUnixMilli() {
+	fmt.Println(ToUnixMilli(futureDate))
+	fmt.Println(FromUnixMilli(32535216000300, time.UTC))
+	fmt.Println(ToUnixMicro(third))
+	fmt.Println(FromUnixMicro(-1, time.UTC))
+	// Output:
+	// 32535216000000
+	// 3001-01-01 00:00:00.3 +0000 UTC
+	// 1465193166000000
+	// 1969-12-31 23:59:59.999999 +0000 UTC
+}
+
+func ExampleToNTPTimestamp() {
+	timestamp := ToNTPTimestamp(time.Date(2017, 1, 1, 0, 0, 0, 5e8, time.UTC))
+	fmt.Printf("%016x\n", timestamp)
+	fmt.Println(FromNTPTimestamp(timestamp, time.UTC))
+	// the first second of NTP era 1
+	fmt.Println(FromNTPTimestamp(0, time.UTC))
+	// Output:
+	// dc12c50080000000
+	// 2017-01-01 00:00:00.5 +0000 UTC
+	// 2036-02-07 06:28:16 +0000 UTC
+}
+
+func ExampleToGPSWeekSeconds() {
+	week, sinceStartOfWeek := ToGPSWeekSeconds(time.Date(2017, 1, 4, 12, 0, 0, 0, time.UTC))
+	fmt.Println(week, sinceStartOfWeek)
+	fmt.Println(FromGPSWeekSeconds(week, sinceStartOfWeek, time.UTC))
+	fmt.Println(ToGPSWeekSeconds(time.Date(1980, 1, 5, 0, 0, 0, 0, time.UTC)))
+	// Output:
+	// 1930 84h0m0s
+	// 2017-01-04 12:00:00 +0000 UTC
+	// -1 144h0m0s
+}
+
+func ExampleUTCToTAI() {
+	fmt.Println(UTCToTAI(time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC)))
+	fmt.Println(UTCToTAI(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)))
+	// Output:
+	// 2017-01-01 00:00:35 +0000 UTC
+	// 2017-01-01 00:00:37 +0000 UTC
+}
+
+func ExampleTAIToUTC() {
+	fmt.Println(TAIToUTC(time.Date(2017, 1, 1, 0, 0, 35, 5e8, time.UTC)))
+	// inside the leap second 2016-12-31 23:59:60
+	fmt.Println(TAIToUTC(time.Date(2017, 1, 1, 0, 0, 36, 5e8, time.UTC)))
+	fmt.Println(TAIToUTC(time.Date(2017, 1, 1, 0, 0, 37, 0, time.UTC)))
+	// Output:
+	// 2016-12-31 23:59:59.5 +0000 UTC
+	// 2016-12-31 23:59:59.5 +0000 UTC
+	// 2017-01-01 00:00:00 +0000 UTC
+}
+
+func ExampleGPSToUTC() {
+	fmt.Println(GPSToUTC(time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(GPSToUTC(time.Date(2017, 1, 1, 0, 0, 18, 0, time.UTC)))
+	fmt.Println(UTCToGPS(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)))
+	// Output:
+	// 1980-01-06 00:00:00 +0000 UTC
+	// 2017-01-01 00:00:00 +0000 UTC
+	// 2017-01-01 00:00:18 +0000 UTC
+}
+
+func ExampleLeapSecondsBetween() {
+	fmt.Println(LeapSecondsBetween(time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC), first))
+	fmt.Println(LeapSecondsBetween(first, fourth))
+	// Output:
+	// 18
+	// -2
+}
+
+func ExampleDifferenceInSecondsWithLeapSeconds() {
+	midnight := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	fmt.Println(DifferenceInSeconds(midnight, AddSeconds(midnight, -1)))
+	fmt.Println(DifferenceInSecondsWithLeapSeconds(midnight, AddSeconds(midnight, -1)))
+	// Output:
+	// 1
+	// 2
+}
+
+func ExampleLoadLeapSecondTable() {
+	table, err := LoadLeapSecondTable(strings.NewReader(`
+#@	3900000000
+3692217600	37	# 1 Jan 2017
+3900000000	38	# fictitious leap second
+`))
+	fmt.Println(err)
+	fmt.Println(table.Expires())
+	fmt.Println(table.LeapSecondsBetween(first, futureDate))
+
+	_, err = LoadLeapSecondTable(strings.NewReader("3692217600 thirty-seven"))
+	fmt.Println(err)
+	// Output:
+	// <nil>
+	// 2023-08-02 21:20:00 +0000 UTC
+	// 1
+	// Passed leap second table is malformed, bad entry on line 1
+}
+
+func ExampleSetLeapSecondTable() {
+	fmt.Println(CurrentLeapSecondTable().Expires())
+
+	table, _ := LoadLeapSecondTable(strings.NewReader("3692217600 37\n3900000000 38"))
+	SetLeapSecondTable(table)
+	fmt.Println(LeapSecondsBetween(first, futureDate))
+
+	// nil restores the embedded table
+	SetLeapSecondTable(nil)
+	fmt.Println(LeapSecondsBetween(first, futureDate))
+	// Output:
+	// 2026-12-28 00:00:00 +0000 UTC
+	// 1
+	// 0
+}
+
+func ExampleFiscalCalendar() {
+	// the Japanese fiscal year starts in April and is named after its first year
+	japan := FiscalCalendar{StartMonth: time.April, NameByStartYear: true}
+	fmt.Println(japan.Year(first), japan.Quarter(first), japan.Period(first))
+	fmt.Println(japan.StartOfYear(first))
+	fmt.Println(japan.EndOfYear(first))
+	fmt.Println(japan.StartOfQuarter(first))
+
+	// the US federal fiscal year starts in October and is named after its last year
+	federal := NewFiscalCalendar(time.October)
+	fmt.Println(federal.Year(first), federal.Quarter(first), federal.Week(first))
+	fmt.Println(federal.IsSameQuarter(first, time.Date(2017, 3, 31, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(federal.IsSameQuarter(first, time.Date(2016, 9, 30, 0, 0, 0, 0, time.UTC)))
+	// Output:
+	// 2016 4 10
+	// 2016-04-01 00:00:00 +0000 UTC
+	// 2017-03-31 23:59:59.999999999 +0000 UTC
+	// 2017-01-01 00:00:00 +0000 UTC
+	// 2017 2 14
+	// true
+	// false
+}
+
+func ExampleNewRetailCalendar() {
+	// the NRF 4-5-4 calendar ends on the Saturday nearest the end of January
+	retail := NewRetailCalendar(time.February, time.Saturday, Pattern454)
+	retail.EndNearestWeekday = true
+	retail.NameByStartYear = true
+
+	date := time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)
+	fmt.Println(retail.Year(date), retail.WeeksInYear(2023), retail.WeeksInYear(2024))
+	fmt.Println(retail.Week(date), retail.Period(date), retail.Quarter(date))
+	fmt.Println(retail.StartOfYear(date))
+	fmt.Println(retail.EndOfYear(date))
+	fmt.Println(retail.StartOfQuarter(date))
+	fmt.Println(retail.StartOfPeriod(date))
+	fmt.Println(retail.EndOfPeriod(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(retail.Year(time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)))
+	// Output:
+	// 2023 53 52
+	// 53 12 4
+	// 2023-01-29 00:00:00 +0000 UTC
+	// 2024-02-03 23:59:59.999999999 +0000 UTC
+	// 2023-10-29 00:00:00 +0000 UTC
+	// 2023-12-31 00:00:00 +0000 UTC
+	// 2023-04-01 23:59:59.999999999 +0000 UTC
+	// 2024
+}
+
+func ExampleGetQuarter() {
+	for month := time.January; month <= time.December; month++ {
+		date := time.Date(2016, month, 15, 12, 0, 0, 0, time.UTC)
+		fmt.Println(month, GetQuarter(date), IsFirstQuarter(date), IsSecondQuarter(date), IsThirdQuarter(date), IsFourthQuarter(date))
+	}
+	// Output:
+	// January 1 true false false false
+	// February 1 true false false false
+	// March 1 true false false false
+	// April 2 false true false false
+	// May 2 false true false false
+	// June 2 false true false false
+	// July 3 false false true false
+	// August 3 false false true false
+	// September 3 false false true false
+	// October 4 false false false true
+	// November 4 false false false true
+	// December 4 false false false true
+}
+
+func ExampleStartOfQuarter() {
+	for month := time.January; month <= time.December; month++ {
+		date := time.Date(2016, month, 15, 12, 0, 0, 0, time.UTC)
+		fmt.Println(StartOfQuarter(date).Format("2006-01-02"), LastDayOfQuarter(date).Format("2006-01-02"), EndOfQuarter(date))
+	}
+	// Output:
+	// 2016-01-01 2016-03-31 2016-03-31 23:59:59.999999999 +0000 UTC
+	// 2016-01-01 2016-03-31 2016-03-31 23:59:59.999999999 +0000 UTC
+	// 2016-01-01 2016-03-31 2016-03-31 23:59:59.999999999 +0000 UTC
+	// 2016-04-01 2016-06-30 2016-06-30 23:59:59.999999999 +0000 UTC
+	// 2016-04-01 2016-06-30 2016-06-30 23:59:59.999999999 +0000 UTC
+	// 2016-04-01 2016-06-30 2016-06-30 23:59:59.999999999 +0000 UTC
+	// 2016-07-01 2016-09-30 2016-09-30 23:59:59.999999999 +0000 UTC
+	// 2016-07-01 2016-09-30 2016-09-30 23:59:59.999999999 +0000 UTC
+	// 2016-07-01 2016-09-30 2016-09-30 23:59:59.999999999 +0000 UTC
+	// 2016-10-01 2016-12-31 2016-12-31 23:59:59.999999999 +0000 UTC
+	// 2016-10-01 2016-12-31 2016-12-31 23:59:59.999999999 +0000 UTC
+	// 2016-10-01 2016-12-31 2016-12-31 23:59:59.999999999 +0000 UTC
+}
+
+func ExampleGetDaysInQuarter() {
+	for quarter := 1; quarter <= 4; quarter++ {
+		leap, _ := SetQuarter(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), quarter)
+		common, _ := SetQuarter(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), quarter)
+		fmt.Println(quarter, GetDaysInQuarter(leap), GetDaysInQuarter(common))
+	}
+	// Output:
+	// 1 91 90
+	// 2 91 91
+	// 3 92 92
+	// 4 92 92
+}
+
+func ExampleSetQuarter() {
+	fmt.Println(SetQuarter(time.Date(2017, 5, 31, 6, 0, 0, 0, time.UTC), 1))
+	fmt.Println(SetQuarter(time.Date(2017, 5, 31, 6, 0, 0, 0, time.UTC), 4))
+	fmt.Println(SetQuarter(time.Date(2017, 3, 31, 6, 0, 0, 0, time.UTC), 2))
+	fmt.Println(SetQuarter(first, 5))
+	// Output:
+	// 2017-02-28 06:00:00 +0000 UTC <nil>
+	// 2017-11-30 06:00:00 +0000 UTC <nil>
+	// 2017-06-30 06:00:00 +0000 UTC <nil>
+	// 2017-01-01 00:00:00 +0000 UTC Passed quarter 5 is out of range 1 to 4
+}
+
+func ExampleDifferenceInCalendarQuarters() {
+	fmt.Println(DifferenceInCalendarQuarters(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC)))
+	fmt.Println(DifferenceInCalendarQuarters(time.Date(2016, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(DifferenceInCalendarQuarters(fourth, first))
+	// Output:
+	// 1
+	// 3
+	// -8
+}
+
+func ExampleDifferenceInQuarters() {
+	fmt.Println(DifferenceInQuarters(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC)))
+	fmt.Println(DifferenceInQuarters(time.Date(2017, 3, 31, 0, 0, 0, 0, time.UTC), time.Date(2016, 12, 31, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(DifferenceInQuarters(time.Date(2017, 3, 30, 0, 0, 0, 0, time.UTC), time.Date(2016, 12, 31, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(DifferenceInQuarters(fourth, first))
+	// quarter ends are a full quarter apart however long their months are
+	fmt.Println(DifferenceInQuarters(time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(DifferenceInQuarters(time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(DifferenceInQuarters(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(DifferenceInQuarters(time.Date(2024, 5, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(DifferenceInQuarters(time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(DifferenceInQuarters(time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)))
+	// Output:
+	// 0
+	// 1
+	// 0
+	// -7
+	// 1
+	// 1
+	// -1
+	// 1
+	// 1
+	// 0
+}
+
+func ExampleEachQuarterOfInterval() {
+	fmt.Println(EachQuarterOfInterval(time.Date(2016, 11, 15, 0, 0, 0, 0, time.UTC), time.Date(2017, 4, 1, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(EachQuarterOfInterval(first, fourth))
+	// Output:
+	// [2016-10-01 00:00:00 +0000 UTC 2017-01-01 00:00:00 +0000 UTC 2017-04-01 00:00:00 +0000 UTC] <nil>
+	// [] End date can not be before start date. Returned empty slice.
+}
+
+func ExampleIsSameQuarter() {
+	fmt.Println(IsSameQuarter(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2017, 3, 31, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(IsSameQuarter(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2016, 12, 31, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(IsSameQuarter(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)))
+	// Output:
+	// true
+	// false
+	// false
+}
+
+type event struct {
+	Name string
+	At   time.Time
+}
+
+var events = []event{
+	{"launch", first},
+	{"beta", second},
+	{"alpha", second},
+	{"patch", third},
+	{"kickoff", fourth},
+}
+
+func eventTime(e event) time.Time {
+	return e.At
+}
+
+func ExampleSortBy() {
+	sorted := append([]event{}, events...)
+	SortBy(sorted, eventTime, ASC)
+	fmt.Println(sorted)
+
+	SortBy(sorted, eventTime, DESC, func(a, b event) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	fmt.Println(sorted)
+	// Output:
+	// [{kickoff 2015-01-01 01:00:00 +0000 UTC} {beta 2016-06-06 06:06:06.000000006 +0000 UTC} {alpha 2016-06-06 06:06:06.000000006 +0000 UTC} {patch 2016-06-06 06:06:06.000000007 +0000 UTC} {launch 2017-01-01 00:00:00 +0000 UTC}]
+	// [{launch 2017-01-01 00:00:00 +0000 UTC} {patch 2016-06-06 06:06:06.000000007 +0000 UTC} {alpha 2016-06-06 06:06:06.000000006 +0000 UTC} {beta 2016-06-06 06:06:06.000000006 +0000 UTC} {kickoff 2015-01-01 01:00:00 +0000 UTC}]
+}
+
+func ExampleClosestBy() {
+	fmt.Println(ClosestIndexBy(third.Add(-time.Nanosecond), events, eventTime))
+	fmt.Println(ClosestBy(futureDate, events, eventTime))
+	fmt.Println(ClosestBy(futureDate, []event{}, eventTime))
+	// Output:
+	// 1 <nil>
+	// {launch 2017-01-01 00:00:00 +0000 UTC} <nil>
+	// { 0001-01-01 00:00:00 +0000 UTC} Passed slice of dates was of size 0
+}
+
+func ExampleMaxBy() {
+	fmt.Println(MaxBy(events, eventTime))
+	fmt.Println(MinBy(events, eventTime))
+	fmt.Println(MaxBy(events[1:3], eventTime))
+	fmt.Println(MinBy(nil, eventTime))
+	// Output:
+	// {launch 2017-01-01 00:00:00 +0000 UTC} <nil>
+	// {kickoff 2015-01-01 01:00:00 +0000 UTC} <nil>
+	// {beta 2016-06-06 06:06:06.000000006 +0000 UTC} <nil>
+	// { 0001-01-01 00:00:00 +0000 UTC} Passed slice of dates was nil
+}
+
+func ExampleTimeIndex() {
+	index := NewTimeIndex([]time.Time{first, fourth, third, second, futureDate})
+
+	closest, _ := index.Closest(time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC))
+	fmt.Println(closest, index.At(closest))
+
+	floor, _ := index.Floor(time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC))
+	ceiling, _ := index.Ceiling(time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC))
+	fmt.Println(floor, ceiling)
+
+	fmt.Println(index.Floor(pastDate))
+	fmt.Println(index.Ceiling(AddDays(futureDate, 1)))
+	fmt.Println(NewTimeIndex(nil).Closest(first))
+	// Output:
+	// 2 2016-06-06 06:06:06.000000007 +0000 UTC
+	// 2 3
+	// 0 No date in the index is before or equal to the passed date
+	// 0 No date in the index is after or equal to the passed date
+	// 0 Time index is empty
+}
+
+func ExampleTimeIndex_RangeQuery() {
+	index := NewTimeIndex([]time.Time{first, fourth, third, second, futureDate})
+	fmt.Println(index.RangeQuery(second, first))
+	fmt.Println(index.CountBetween(second, first), index.CountBetween(fourth, futureDate), index.CountBetween(first, fourth))
+	fmt.Println(index.Rank(third), index.Rank(pastDate), index.Rank(AddDays(futureDate, 1)))
+	// Output:
+	// [2016-06-06 06:06:06.000000006 +0000 UTC 2016-06-06 06:06:06.000000007 +0000 UTC]
+	// 2 4 0
+	// 2 0 5
+}
+
+func ExampleIntervalTree() {
+	day := func(d int) time.Time {
+		return time.Date(2017, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	bookings := NewIntervalTree[string]()
+	bookings.Insert(TimeRange{day(1), day(5)}, "Alice")
+	bookings.Insert(TimeRange{day(5), day(8)}, "Bob")
+	bookings.Insert(TimeRange{day(3), day(10)}, "Carol")
+	fmt.Println(bookings.Insert(TimeRange{day(3), day(1)}, "Dave"))
+
+	for _, entry := range bookings.Overlapping(TimeRange{day(4), day(6)}) {
+		fmt.Println(entry.Value, entry.Range.Start.Day(), entry.Range.End.Day())
+	}
+	fmt.Println(len(bookings.Stabbing(day(5))))
+	fmt.Println(bookings.AnyOverlap(TimeRange{day(10), day(12)}))
+
+	fmt.Println(bookings.Delete(TimeRange{day(3), day(10)}, func(name string) bool { return name == "Carol" }))
+	fmt.Println(bookings.Delete(TimeRange{day(3), day(10)}, nil))
+	fmt.Println(bookings.Len(), bookings.AnyOverlap(TimeRange{day(8), day(12)}))
+	// Output:
+	// End date can not be before start date. Range was not inserted.
+	// Alice 1 5
+	// Carol 3 10
+	// Bob 5 8
+	// 2
+	// false
+	// true
+	// false
+	// 2 false
+}
+
+func ExampleStartOfWeekOn() {
+	fmt.Println(StartOfWeekOn(first, time.Monday))
+	fmt.Println(EndOfWeekOn(first, time.Monday))
+	fmt.Println(StartOfWeekOn(first, time.Sunday))
+	// Output:
+	// 2016-12-26 00:00:00 +0000 UTC
+	// 2017-01-01 23:59:59.999999999 +0000 UTC
+	// 2017-01-01 00:00:00 +0000 UTC
+}
+
+func ExampleBucket() {
+	newYork, _ := time.LoadLocation("America/New_York")
+	// 01:30 happens twice when summer time ends
+	firstPass := time.Date(2016, 11, 6, 5, 30, 0, 0, time.UTC)
+	secondPass := time.Date(2016, 11, 6, 6, 30, 0, 0, time.UTC)
+	fmt.Println(Bucket(firstPass, UnitHour, BucketOptions{Location: newYork}))
+	fmt.Println(Bucket(secondPass, UnitHour, BucketOptions{Location: newYork}))
+	fmt.Println(Bucket(secondPass, UnitDay, BucketOptions{Location: newYork}).Duration())
+	fmt.Println(Bucket(third, UnitWeek, BucketOptions{WeekStart: time.Monday}))
+	// Output:
+	// {2016-11-06 01:00:00 -0400 EDT 2016-11-06 01:00:00 -0500 EST}
+	// {2016-11-06 01:00:00 -0500 EST 2016-11-06 02:00:00 -0500 EST}
+	// 25h0m0s
+	// {2016-06-06 00:00:00 +0000 UTC 2016-06-13 00:00:00 +0000 UTC}
+}
+
+func ExampleGroupBy() {
+	buckets := GroupBy(events, eventTime, UnitYear, BucketOptions{})
+	for _, bucket := range buckets {
+		fmt.Println(bucket.Range.Start.Year(), len(bucket.Items))
+	}
+
+	visits := []time.Time{
+		time.Date(2017, 1, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2017, 1, 2, 17, 0, 0, 0, time.UTC),
+		time.Date(2017, 1, 4, 9, 0, 0, 0, time.UTC),
+	}
+	byDay := GroupBy(visits, func(date time.Time) time.Time { return date }, UnitDay, BucketOptions{
+		To: time.Date(2017, 1, 5, 0, 0, 0, 0, time.UTC),
+	})
+	for _, bucket := range byDay {
+		fmt.Println(bucket.Range.Start.Format("2006-01-02"), bucket.Items)
+	}
+	// Output:
+	// 2015 1
+	// 2016 3
+	// 2017 1
+	// 2017-01-02 [2017-01-02 09:00:00 +0000 UTC 2017-01-02 17:00:00 +0000 UTC]
+	// 2017-01-03 []
+	// 2017-01-04 [2017-01-04 09:00:00 +0000 UTC]
+	// 2017-01-05 []
+}
+
+func ExampleRoundToNearest() {
+	date := time.Date(2017, 1, 1, 10, 7, 30, 0, time.UTC)
+	fmt.Println(RoundToNearest(date, UnitMinute, 15, RoundHalfUp))
+	fmt.Println(RoundToNearest(date, UnitMinute, 15, RoundCeil))
+	fmt.Println(RoundToNearest(date, UnitMinute, 15, RoundFloor))
+	fmt.Println(RoundToNearest(date, UnitSecond, 5, RoundHalfUp))
+	fmt.Println(RoundToNearest(date, UnitHour, 6, RoundHalfUp))
+	fmt.Println(RoundToNearest(date, UnitHour, 12, RoundHalfUp))
+	fmt.Println(RoundToNearest(date, UnitMinute, 7, RoundCeil))
+	fmt.Println(RoundToNearest(date, UnitMinute, 0, RoundCeil))
+	// Output:
+	// 2017-01-01 10:15:00 +0000 UTC <nil>
+	// 2017-01-01 10:15:00 +0000 UTC <nil>
+	// 2017-01-01 10:00:00 +0000 UTC <nil>
+	// 2017-01-01 10:07:30 +0000 UTC <nil>
+	// 2017-01-01 12:00:00 +0000 UTC <nil>
+	// 2017-01-01 12:00:00 +0000 UTC <nil>
+	// 2017-01-01 10:14:00 +0000 UTC <nil>
+	// 2017-01-01 10:07:30 +0000 UTC Passed step was less than 1. Date left unchanged.
+}
+
+func ExampleRoundToNearest_halfEven() {
+	fmt.Println(RoundToNearest(time.Date(2017, 1, 1, 10, 7, 30, 0, time.UTC), UnitMinute, 15, RoundHalfEven))
+	fmt.Println(RoundToNearest(time.Date(2017, 1, 1, 10, 22, 30, 0, time.UTC), UnitMinute, 15, RoundHalfEven))
+	fmt.Println(RoundToNearest(time.Date(2017, 1, 1, 10, 22, 30, 0, time.UTC), UnitMinute, 15, RoundHalfUp))
+	// Output:
+	// 2017-01-01 10:00:00 +0000 UTC <nil>
+	// 2017-01-01 10:30:00 +0000 UTC <nil>
+	// 2017-01-01 10:30:00 +0000 UTC <nil>
+}
+
+func ExampleRoundToNearest_calendarUnits() {
+	fmt.Println(RoundToNearest(time.Date(2017, 2, 14, 0, 0, 0, 0, time.UTC), UnitMonth, 1, RoundHalfUp))
+	fmt.Println(RoundToNearest(time.Date(2017, 2, 15, 0, 0, 0, 0, time.UTC), UnitMonth, 1, RoundHalfUp))
+	fmt.Println(RoundToNearest(time.Date(2017, 5, 20, 0, 0, 0, 0, time.UTC), UnitQuarter, 1, RoundHalfUp))
+	fmt.Println(RoundToNearest(time.Date(2017, 11, 20, 0, 0, 0, 0, time.UTC), UnitMonth, 6, RoundCeil))
+	fmt.Println(RoundToNearest(time.Date(2017, 1, 4, 12, 0, 0, 0, time.UTC), UnitWeek, 1, RoundHalfUp))
+	fmt.Println(RoundToNearest(time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC), UnitWeek, 1, RoundFloor))
+	fmt.Println(RoundToNearest(time.Date(2017, 1, 4, 12, 0, 0, 0, time.UTC), UnitYear, 10, RoundHalfUp))
+	// Output:
+	// 2017-02-01 00:00:00 +0000 UTC <nil>
+	// 2017-03-01 00:00:00 +0000 UTC <nil>
+	// 2017-07-01 00:00:00 +0000 UTC <nil>
+	// 2018-01-01 00:00:00 +0000 UTC <nil>
+	// 2017-01-08 00:00:00 +0000 UTC <nil>
+	// 2024-01-28 00:00:00 +0000 UTC <nil>
+	// 2020-01-01 00:00:00 +0000 UTC <nil>
+}
+
+func ExampleRoundToNearest_daylightSaving() {
+	newYork, _ := time.LoadLocation("America/New_York")
+	// the day summer time starts has no 02:00, six hour steps still follow the wall clock
+	fmt.Println(RoundToNearest(time.Date(2017, 3, 12, 5, 0, 0, 0, newYork), UnitHour, 6, RoundHalfUp))
+	// the second 01:50 after summer time ends rounds within the repeated hour
+	fmt.Println(RoundToNearest(time.Date(2016, 11, 6, 6, 50, 0, 0, time.UTC).In(newYork), UnitMinute, 15, RoundFloor))
+	// Output:
+	// 2017-03-12 06:00:00 -0400 EDT <nil>
+	// 2016-11-06 01:45:00 -0500 EST <nil>
+}
+
+var heartbeats = []time.Time{
+	time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(2017, 1, 1, 1, 0, 0, 0, time.UTC),
+	time.Date(2017, 1, 1, 2, 0, 30, 0, time.UTC),
+	time.Date(2017, 1, 1, 5, 0, 0, 0, time.UTC),
+	time.Date(2017, 1, 1, 5, 0, 0, 0, time.UTC),
+	time.Date(2017, 1, 1, 5, 20, 0, 0, time.UTC),
+	time.Date(2017, 1, 1, 6, 0, 0, 0, time.UTC),
+}
+
+func ExampleGaps() {
+	for _, gap := range Gaps(heartbeats, time.Hour, time.Minute) {
+		fmt.Println(gap.Range.Start.Format("15:04:05"), gap.Range.End.Format("15:04:05"), gap.Missing)
+	}
+	// Output:
+	// 02:00:30 05:00:00 2
+}
+
+func ExampleIrregularities() {
+	for _, irregular := range Irregularities(heartbeats, time.Hour, time.Minute) {
+		fmt.Println(irregular.Start.Format("15:04:05"), irregular.End.Format("15:04:05"))
+	}
+	// Output:
+	// 02:00:30 05:00:00
+	// 05:00:00 05:00:00
+	// 05:00:00 05:20:00
+	// 05:20:00 06:00:00
+}
+
+func ExampleDuplicates() {
+	fmt.Println(Duplicates(heartbeats))
+	fmt.Println(Duplicates([]time.Time{first, second}))
+	// Output:
+	// [2017-01-01 05:00:00 +0000 UTC]
+	// []
+}
+
+func ExampleMedianInterval() {
+	fmt.Println(MedianInterval(heartbeats))
+	fmt.Println(MedianInterval([]time.Time{first}))
+	// Output:
+	// 50m0s <nil>
+	// 0s Passed slice of dates has less than 2 dates
+}
+
+func ExampleDetectCadence() {
+	newYork, _ := time.LoadLocation("America/New_York")
+	var daily, monthly []time.Time
+	for i := 0; i < 10; i++ {
+		daily = append(daily, time.Date(2017, 3, 8+i, 9, 0, 0, 0, newYork))
+		monthly = append(monthly, time.Date(2017, time.Month(1+i), 1, 0, 0, 0, 0, time.UTC))
+	}
+	fmt.Println(DetectCadence(heartbeats[:3]))
+	fmt.Println(DetectCadence(daily))
+	fmt.Println(DetectCadence(monthly))
+	fmt.Println(DetectCadence([]time.Time{first, futureDate}))
+	fmt.Println(DetectCadence(nil))
+	fmt.Println(DetectCadence([]time.Time{first}))
+	// Output:
+	// hour <nil>
+	// day <nil>
+	// month <nil>
+	// unknown Passed dates have no hourly, daily, weekly or monthly cadence
+	// unknown Passed slice of dates was nil
+	// unknown Passed slice of dates has less than 2 dates
+}
+
+func ExampleMean() {
+	fmt.Println(Mean([]time.Time{first, fourth}))
+	fmt.Println(Mean([]time.Time{second, third, third}))
+	fmt.Println(Mean(nil))
+	fmt.Println(errors.Is(func() error { _, err := Mean([]time.Time{}); return err }(), ErrEmptySlice))
+	// Output:
+	// 2016-01-01 12:30:00 +0000 UTC <nil>
+	// 2016-06-06 06:06:06.000000006 +0000 UTC <nil>
+	// 0001-01-01 00:00:00 +0000 UTC Passed slice of dates was nil
+	// true
+}
+
+func ExampleMedian() {
+	fmt.Println(Median([]time.Time{first, fourth, second}))
+	fmt.Println(Median([]time.Time{first, fourth}))
+	// Output:
+	// 2016-06-06 06:06:06.000000006 +0000 UTC <nil>
+	// 2016-01-01 12:30:00 +0000 UTC <nil>
+}
+
+func ExamplePercentile() {
+	var hours []time.Time
+	for i := 0; i <= 10; i++ {
+		hours = append(hours, AddHours(first, i))
+	}
+	fmt.Println(Percentile(hours, 90))
+	fmt.Println(Percentile(hours, 95))
+	fmt.Println(Percentile(hours, 100))
+	fmt.Println(Percentile(hours, 101))
+	// Output:
+	// 2017-01-01 09:00:00 +0000 UTC <nil>
+	// 2017-01-01 09:30:00 +0000 UTC <nil>
+	// 2017-01-01 10:00:00 +0000 UTC <nil>
+	// 0001-01-01 00:00:00 +0000 UTC Passed percentile was less than 0 or more than 100
+}
+
+func ExampleSpan() {
+	fmt.Println(Span([]time.Time{second, third, fourth}))
+	fmt.Println(Span(nil))
+	// Output:
+	// 12533h6m6.000000007s <nil>
+	// 0s Passed slice of dates was nil
+}
+
+func ExampleModeDay() {
+	fmt.Println(ModeDay(heartbeats))
+	fmt.Println(ModeWeekday([]time.Time{first, second, third, fourth}))
+	fmt.Println(ModeHourOfDay(heartbeats))
+	fmt.Println(ModeHourOfDay([]time.Time{}))
+	// Output:
+	// 2017-01-01 00:00:00 +0000 UTC 7 <nil>
+	// Monday 2 <nil>
+	// 5 3 <nil>
+	// 0 0 Passed slice of dates was of size 0
+}
+
+func ExampleCircularMeanTimeOfDay() {
+	fmt.Println(CircularMeanTimeOfDay([]time.Time{
+		time.Date(2017, 1, 1, 23, 0, 0, 0, time.UTC),
+		time.Date(2017, 1, 2, 1, 0, 0, 0, time.UTC),
+	}))
+	fmt.Println(CircularMeanTimeOfDay([]time.Time{
+		time.Date(2017, 1, 1, 22, 0, 0, 0, time.UTC),
+		time.Date(2017, 1, 2, 23, 0, 0, 0, time.UTC),
+		time.Date(2017, 1, 3, 3, 0, 0, 0, time.UTC),
+	}))
+	fmt.Println(CircularMeanTimeOfDay([]time.Time{
+		time.Date(2017, 1, 1, 6, 0, 0, 0, time.UTC),
+		time.Date(2017, 1, 1, 18, 0, 0, 0, time.UTC),
+	}))
+	// Output:
+	// 0s <nil>
+	// 23h55m19.976s <nil>
+	// 0s Passed times of day cancel out and have no mean
+}
+
+func ExampleRangeError() {
+	_, err := SetHours(futureDate, 24)
+	var rangeErr *RangeError
+	if errors.As(err, &rangeErr) {
+		fmt.Println(rangeErr.Field, rangeErr.Value, rangeErr.Min, rangeErr.Max)
+	}
+	fmt.Println(errors.Is(err, ErrOutOfRange))
+
+	_, err = EachQuarterOfInterval(futureDate, pastDate)
+	fmt.Println(errors.Is(err, ErrEndBeforeStart))
+
+	_, err = SetDayOfMonth(time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC), 29)
+	fmt.Println(err)
+	// Output:
+	// hour 24 0 23
+	// true
+	// true
+	// Passed day of month 29 is out of range 1 to 28
+}
+
+func ExampleSetDayOfMonth() {
+	march := time.Date(2017, 3, 15, 10, 0, 0, 0, time.UTC)
+	fmt.Println(SetDayOfMonth(march, 31))
+	fmt.Println(SetDayOfMonth(march, 0))
+	fmt.Println(SetDayOfMonth(march, -40))
+	fmt.Println(SetDayOfMonth(march, 32))
+	// Output:
+	// 2017-03-31 10:00:00 +0000 UTC <nil>
+	// 0001-01-01 00:00:00 +0000 UTC Passed day of month 0 is out of range 1 to 31
+	// 0001-01-01 00:00:00 +0000 UTC Passed day of month -40 is out of range 1 to 31
+	// 0001-01-01 00:00:00 +0000 UTC Passed day of month 32 is out of range 1 to 31
+}
+
+func ExampleIsValidDate() {
+	fmt.Println(IsValidDate(2016, time.February, 29))
+	fmt.Println(IsValidDate(2017, time.February, 29))
+	fmt.Println(IsValidDate(2017, 13, 1))
+	// Output:
+	// true
+	// false
+	// false
+}
+
+func ExampleIsValidTime() {
+	fmt.Println(IsValidTime(23, 59, 59, 999999999))
+	fmt.Println(IsValidTime(24, 0, 0, 0))
+	// Output:
+	// true
+	// false
+}
+
+func ExampleBuilder() {
+	fmt.Println(New().Year(2024).Month(2).Day(29).Hour(6).Minute(30).Build())
+	fmt.Println(New().Year(2024).Month(2).Day(30).Build())
+	fmt.Println(New().Year(2024).Month(2).Day(1).Minute(60).Build())
+	// Output:
+	// 2024-02-29 06:30:00 +0000 UTC <nil>
+	// 0001-01-01 00:00:00 +0000 UTC Passed day of month 30 is out of range 1 to 29
+	// 0001-01-01 00:00:00 +0000 UTC Passed minute 60 is out of range 0 to 59
+}
+
+func ExampleAge() {
+	leapling := time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2017, 2, 28, 12, 0, 0, 0, time.UTC)
+	fmt.Println(Age(leapling, at, LeapDayOnFebruary28))
+	fmt.Println(Age(leapling, at, LeapDayOnMarch1))
+	fmt.Println(Age(second, first, LeapDayOnFebruary28))
+	fmt.Println(Age(first, second, LeapDayOnFebruary28))
+	// Output:
+	// 17
+	// 16
+	// 0
+	// 0
+}
+
+func ExampleAgeBreakdown() {
+	birth := time.Date(1990, 1, 31, 8, 0, 0, 0, time.UTC)
+	fmt.Println(AgeBreakdown(birth, time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC), LeapDayOnFebruary28))
+	fmt.Println(AgeBreakdown(fourth, second, LeapDayOnFebruary28))
+	// Output:
+	// 27 1 1
+	// 1 5 5
+}
+
+func ExampleNextAnniversary() {
+	leapling := time.Date(2000, 2, 29, 9, 0, 0, 0, time.UTC)
+	fmt.Println(NextAnniversary(leapling, first, LeapDayOnFebruary28))
+	fmt.Println(NextAnniversary(leapling, first, LeapDayOnMarch1))
+	fmt.Println(NextAnniversary(second, second, LeapDayOnFebruary28))
+	// Output:
+	// 2017-02-28 09:00:00 +0000 UTC
+	// 2017-03-01 09:00:00 +0000 UTC
+	// 2017-06-06 06:06:06.000000006 +0000 UTC
+}
+
+func ExampleIsBirthday() {
+	leapling := time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)
+	fmt.Println(IsBirthday(leapling, time.Date(2017, 2, 28, 23, 0, 0, 0, time.UTC), LeapDayOnFebruary28))
+	fmt.Println(IsBirthday(leapling, time.Date(2017, 2, 28, 23, 0, 0, 0, time.UTC), LeapDayOnMarch1))
+	fmt.Println(IsBirthday(leapling, time.Date(2016, 2, 29, 0, 0, 0, 0, time.UTC), LeapDayOnMarch1))
+	// Output:
+	// true
+	// false
+	// true
+}
+
+func ExampleWorkingSchedule() {
+	newYork, _ := time.LoadLocation("America/New_York")
+	schedule := NewMonToFriSchedule(newYork, 9*time.Hour, 17*time.Hour)
+	schedule.IsHoliday = func(day time.Time) bool {
+		return day.Month() == time.July && day.Day() == 4
+	}
+
+	// Friday evening, the weekend and the holiday on Tuesday do not count
+	friday := time.Date(2017, 6, 30, 15, 0, 0, 0, newYork)
+	deadline, _ := schedule.AddWorkingDuration(friday, 8*time.Hour)
+	fmt.Println(deadline)
+	fmt.Println(schedule.WorkingDurationBetween(friday, deadline))
+	fmt.Println(schedule.IsWithinWorkingHours(friday))
+	fmt.Println(schedule.NextOpening(time.Date(2017, 7, 3, 18, 0, 0, 0, newYork)))
+	fmt.Println(schedule.NextClosing(friday))
+	fmt.Println(WorkingSchedule{}.NextOpening(friday))
+	// Output:
+	// 2017-07-03 15:00:00 -0400 EDT
+	// 8h0m0s
+	// true
+	// 2017-07-05 09:00:00 -0400 EDT <nil>
+	// 2017-06-30 17:00:00 -0400 EDT <nil>
+	// 0001-01-01 00:00:00 +0000 UTC Working schedule has no working hours within a year
+}
+
+func ExampleWorkingSchedule_overnight() {
+	// open around the clock from Monday morning until Wednesday evening
+	var schedule WorkingSchedule
+	schedule.Week[time.Monday] = []WorkingInterval{{Start: 8 * time.Hour, End: 24 * time.Hour}}
+	schedule.Week[time.Tuesday] = []WorkingInterval{{Start: 0, End: 24 * time.Hour}}
+	schedule.Week[time.Wednesday] = []WorkingInterval{{Start: 0, End: 18 * time.Hour}}
+
+	monday := time.Date(2017, 1, 2, 12, 0, 0, 0, time.UTC)
+	fmt.Println(schedule.NextClosing(monday))
+	fmt.Println(schedule.AddWorkingDuration(monday, 60*time.Hour))
+	// Output:
+	// 2017-01-04 18:00:00 +0000 UTC <nil>
+	// 2017-01-09 14:00:00 +0000 UTC <nil>
+}
+
+func ExampleParseNatural() {
+	// a Sunday
+	reference := time.Date(2017, 1, 1, 10, 30, 0, 0, time.UTC)
+	for _, input := range []string{
+		"next friday",
+		"in 3 days",
+		"tomorrow at 5pm",
+		"last day of next month",
+		"2 weeks ago",
+		"this month",
+		"noon",
+		"the day after",
+	} {
+		date, err := ParseNatural(input, reference, NaturalOptions{})
+		if err != nil {
+			fmt.Println(err)
+		} else if date.IsInstant() {
+			fmt.Println(input, "=>", date.Start)
+		} else {
+			fmt.Println(input, "=>", date.Start, "until", date.End)
+		}
+	}
+	// Output:
+	// next friday => 2017-01-06 00:00:00 +0000 UTC until 2017-01-07 00:00:00 +0000 UTC
+	// in 3 days => 2017-01-04 10:30:00 +0000 UTC
+	// tomorrow at 5pm => 2017-01-02 17:00:00 +0000 UTC
+	// last day of next month => 2017-02-28 00:00:00 +0000 UTC until 2017-03-01 00:00:00 +0000 UTC
+	// 2 weeks ago => 2016-12-18 10:30:00 +0000 UTC
+	// this month => 2017-01-01 00:00:00 +0000 UTC until 2017-02-01 00:00:00 +0000 UTC
+	// noon => 2017-01-01 12:00:00 +0000 UTC
+	// Passed input is not a date the parser understands: "the day after"
+}
+
+func ExampleParseNatural_locale() {
+	german := *EnglishLocale
+	german.Today = "Heute"
+	german.Tomorrow = "Morgen"
+	german.Next = "nächsten"
+	german.In = "in"
+	german.At = "um"
+	german.Weekdays = map[string]time.Weekday{"Montag": time.Monday, "Freitag": time.Friday}
+	german.Units = map[string]Unit{"Tag": UnitDay, "Tagen": UnitDay}
+	german.Numbers = map[string]int{"einem": 1, "zwei": 2}
+
+	payday := func(words []string, reference time.Time) (NaturalDate, bool) {
+		if len(words) != 1 || words[0] != "zahltag" {
+			return NaturalDate{}, false
+		}
+		day := time.Date(reference.Year(), reference.Month(), 25, 0, 0, 0, 0, reference.Location())
+		return NaturalDate{Start: day, End: AddDays(day, 1)}, true
+	}
+
+	opts := NaturalOptions{Locale: &german, Rules: []NaturalRule{payday}}
+	fmt.Println(ParseNatural("Morgen um 17:30", first, opts))
+	fmt.Println(ParseNatural("in zwei Tagen", first, opts))
+	fmt.Println(ParseNatural("Zahltag um 9am", first, opts))
+	fmt.Println(ParseNatural("heute", first, opts))
+	fmt.Println(ParseNatural("Nächsten FREITAG", first, opts))
+	// Output:
+	// {2017-01-02 17:30:00 +0000 UTC 2017-01-02 17:30:00 +0000 UTC} <nil>
+	// {2017-01-03 00:00:00 +0000 UTC 2017-01-03 00:00:00 +0000 UTC} <nil>
+	// {2017-01-25 09:00:00 +0000 UTC 2017-01-25 09:00:00 +0000 UTC} <nil>
+	// {2017-01-01 00:00:00 +0000 UTC 2017-01-02 00:00:00 +0000 UTC} <nil>
+	// {2017-01-06 00:00:00 +0000 UTC 2017-01-07 00:00:00 +0000 UTC} <nil>
+}
+
+func ExampleFormat() {
+	fmt.Println(Format(second, "yyyy-MM-dd HH:mm:ss.SSS XXX"))
+	fmt.Println(Format(second, "EEEE, d MMMM yy 'at' h:mm a, 'Q'Q"))
+	fmt.Println(Format(first, "h 'o''clock' z"))
+	// Output:
+	// 2016-06-06 06:06:06.000 Z
+	// Monday, 6 June 16 at 6:06 AM, Q2
+	// 12 o'clock UTC
+}
+
+func ExampleCalendar() {
+	passover := time.Date(2024, 4, 23, 0, 0, 0, 0, time.UTC)
+	nowruz := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	ramadan := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	fmt.Println(HebrewCalendar{}.FromTime(passover))
+	fmt.Println(SolarHijriCalendar{}.FromTime(nowruz))
+	fmt.Println(IslamicCalendar{}.FromTime(ramadan))
+	fmt.Println(JulianCalendar{}.FromTime(time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(HebrewCalendar{}.ToTime(CalendarDate{Year: 5785, Month: 1, Day: 1}, time.UTC))
+	// Output:
+	// 5784-08-15
+	// 1403-01-01
+	// 1445-09-01
+	// 1582-10-05
+	// 2024-10-03 00:00:00 +0000 UTC
+}
+
+func ExampleAddMonthsIn() {
+	// 30 Shevat 5784, followed by Adar I and Adar II in the leap year
+	shevat := time.Date(2024, 2, 9, 18, 0, 0, 0, time.UTC)
+	fmt.Println(AddMonthsIn(HebrewCalendar{}, shevat, 1))
+	fmt.Println(AddMonthsIn(HebrewCalendar{}, shevat, 2))
+	fmt.Println(HebrewCalendar{}.FromTime(AddMonthsIn(HebrewCalendar{}, shevat, 2)))
+	// Output:
+	// 2024-03-10 18:00:00 +0000 UTC
+	// 2024-04-08 18:00:00 +0000 UTC
+	// 5784-07-29
+}
+
+func ExampleGetDaysInMonthIn() {
+	esfand := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	fmt.Println(GetDaysInMonthIn(SolarHijriCalendar{}, esfand))
+	fmt.Println(IsLeapYearIn(SolarHijriCalendar{}, esfand))
+	fmt.Println(StartOfMonthIn(SolarHijriCalendar{}, esfand))
+	fmt.Println(EndOfMonthIn(SolarHijriCalendar{}, esfand))
+	// Output:
+	// 30
+	// true
+	// 2025-02-19 00:00:00 +0000 UTC
+	// 2025-03-20 23:59:59.999999999 +0000 UTC
+}
+
+func ExampleToJapaneseEra() {
+	era, year, err := ToJapaneseEra(time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC))
+	fmt.Println(era.Kanji, year, err)
+	era, year, _ = ToJapaneseEra(time.Date(2019, 4, 30, 0, 0, 0, 0, time.UTC))
+	fmt.Println(era.Name, year)
+	era, year, _ = ToJapaneseEra(time.Date(1868, 1, 25, 0, 0, 0, 0, time.UTC))
+	fmt.Println(era.Name, year)
+	_, _, err = ToJapaneseEra(time.Date(1868, 1, 24, 23, 59, 59, 0, time.UTC))
+	fmt.Println(err)
+	// Output:
+	// 令和 6 <nil>
+	// Heisei 31
+	// Meiji 1
+	// Passed date is outside of the Japanese era
+}
+
+func ExampleFromJapaneseEra() {
+	fmt.Println(FromJapaneseEra(Reiwa, 1, 5, 1))
+	fmt.Println(FromJapaneseEra(Heisei, 31, 5, 1))
+	fmt.Println(FromJapaneseEra(Showa, 64, 2, 30))
+	fmt.Println(FromJapaneseEra(Meiji, 1, 1, 24))
+	// Output:
+	// 2019-05-01 00:00:00 +0000 UTC <nil>
+	// 0001-01-01 00:00:00 +0000 UTC Passed date is outside of the Japanese era
+	// 0001-01-01 00:00:00 +0000 UTC Passed day of month 30 is out of range 1 to 28
+	// 0001-01-01 00:00:00 +0000 UTC Passed date is outside of the Japanese era
+}
+
+func ExampleToROCYear() {
+	date := time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC)
+	fmt.Println(ToROCYear(date), FromROCYear(113))
+	fmt.Println(ToThaiBuddhistYear(date), FromThaiBuddhistYear(2567))
+	fmt.Println(Format(date, "GGGGn年M月d日"))
+	fmt.Println(Format(date, "民國r年M月d日"))
+	fmt.Println(Format(time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC), "G nn.MM.dd, GG"))
+	fmt.Println(Format(date, "d MMMM b"))
+	// Output:
+	// 113 2024
+	// 2567 2024
+	// 令和6年5月5日
+	// 民國113年5月5日
+	// R 01.05.01, Reiwa
+	// 5 May 2567
+}
+
+func ExampleSunrise() {
+	london, _ := time.LoadLocation("Europe/London")
+	solstice := time.Date(2024, 6, 21, 0, 0, 0, 0, london)
+	sunrise, _ := Sunrise(solstice, 51.5074, -0.1278)
+	sunset, _ := Sunset(solstice, 51.5074, -0.1278)
+	fmt.Println(sunrise.Format("15:04 MST"), sunset.Format("15:04 MST"))
+	fmt.Println(SolarNoon(solstice, 51.5074, -0.1278).Format("15:04"))
+	fmt.Println(DayLength(solstice, 51.5074, -0.1278).Round(time.Minute))
+
+	// Tromsø has midnight sun in June and polar night in December
+	fmt.Println(Sunrise(time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), 69.6492, 18.9553))
+	fmt.Println(Sunrise(time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC), 69.6492, 18.9553))
+	fmt.Println(DayLength(time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC), 69.6492, 18.9553))
+	// Output:
+	// 04:43 BST 21:21 BST
+	// 13:02
+	// 16h38m0s
+	// 0001-01-01 00:00:00 +0000 UTC Sun stays above the altitude of the event all day
+	// 0001-01-01 00:00:00 +0000 UTC Sun stays below the altitude of the event all day
+	// 0s
+}
+
+func ExampleCivilDawn() {
+	newYork, _ := time.LoadLocation("America/New_York")
+	equinox := time.Date(2024, 3, 20, 0, 0, 0, 0, newYork)
+	for _, event := range []func(time.Time, float64, float64) (time.Time, error){
+		AstronomicalDawn, NauticalDawn, CivilDawn, CivilDusk, NauticalDusk, AstronomicalDusk,
+	} {
+		at, _ := event(equinox, 40.7128, -74.0060)
+		fmt.Println(at.Format("15:04"))
+	}
+	// Output:
+	// 05:26
+	// 05:59
+	// 06:31
+	// 19:36
+	// 20:08
+	// 20:40
+}
+
+func ExampleMoonPhase() {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fmt.Println(NextNewMoon(start).Format("2006-01-02 15:04"))
+	fmt.Println(NextFullMoon(start).Format("2006-01-02 15:04"))
+
+	phase := MoonPhase(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC))
+	fmt.Printf("%.2f %v\n", phase.Fraction, phase.Name)
+	fmt.Printf("%.2f\n", MoonIllumination(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)))
+	// Output:
+	// 2024-01-11 11:57
+	// 2024-01-25 17:54
+	// 0.29 waxing gibbous
+	// 0.70
+}
+
+func ExampleMoonPhasesInInterval() {
+	start := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	for _, event := range MoonPhasesInInterval(start, start.AddDate(0, 1, 0)) {
+		fmt.Println(event.Time.Round(time.Minute).Format("Jan 2 15:04"), event.Phase)
+	}
+	// Output:
+	// Feb 2 23:18 last quarter
+	// Feb 9 22:59 new moon
+	// Feb 16 15:01 first quarter
+	// Feb 24 12:30 full moon
+}
+
+func ExampleUntil() {
+	now := time.Date(2024, 12, 29, 10, 30, 15, 500000000, time.UTC)
+	countdown := Until(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), now)
+	fmt.Println(countdown.Days, countdown.Hours, countdown.Minutes, countdown.Seconds, countdown.IsExpired())
+	fmt.Println(Until(now, now).IsExpired())
+	// Output:
+	// 2 13 29 44 false
+	// true
+}
+
+// manualClock is a clock whose time moves only when someone waits for it
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	fired := make(chan time.Time, 1)
+	fired <- c.now
+	return fired
+}
+
+func ExampleCountdownTicker() {
+	clock := &manualClock{now: time.Date(2024, 12, 31, 23, 59, 57, 250000000, time.UTC)}
+	target := time.Date(2024, 12, 31, 23, 59, 59, 500000000, time.UTC)
+	for countdown := range CountdownTicker(context.Background(), target, clock) {
+		fmt.Println(countdown.Remaining, countdown.Seconds, countdown.IsExpired())
+	}
+	// Output:
+	// 2.25s 2 false
+	// 1.5s 1 false
+	// 500ms 0 false
+	// 0s 0 true
+}
+
+func ExampleMonthGrid() {
+	christmas := func(day time.Time) bool { return day.Month() == time.December && day.Day() == 25 }
+	opts := MonthGridOptions{WeekStart: time.Monday, Today: time.Date(2024, 12, 10, 15, 0, 0, 0, time.UTC), IsHoliday: christmas}
+	for _, week := range MonthGrid(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), opts) {
+		fmt.Printf("%2d |", week.WeekNumber)
+		for _, day := range week.Days {
+			mark := " "
+			switch {
+			case day.IsToday:
+				mark = "*"
+			case day.IsHoliday:
+				mark = "!"
+			case day.IsOtherMonth:
+				mark = "."
+			case day.IsWeekend:
+				mark = "~"
+			}
+			fmt.Printf(" %2d%s", day.Date.Day(), mark)
+		}
+		fmt.Println()
+	}
+
+	opts = MonthGridOptions{WeekStart: time.Sunday, Minimal: true, Today: time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)}
+	fmt.Println(len(MonthGrid(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), opts)))
+	// Output:
+	// 48 | 25. 26. 27. 28. 29. 30.  1~
+	// 49 |  2   3   4   5   6   7~  8~
+	// 50 |  9  10* 11  12  13  14~ 15~
+	// 51 | 16  17  18  19  20  21~ 22~
+	// 52 | 23  24  25! 26  27  28~ 29~
+	//  1 | 30  31   1.  2.  3.  4.  5.
+	// 4
+}
@@ -0,0 +1,231 @@
+package thl
+
+import (
+	"time"
+)
+
+/**********************
+ *** Fiscal Helpers ***
+ **********************/
+
+// RetailPattern is the number of weeks in each of the three periods of a retail quarter
+type RetailPattern int
+
+const (
+	// MonthBased fiscal years use calendar months as periods
+	MonthBased RetailPattern = iota
+	// Pattern445 quarters have periods of 4, 4 and 5 weeks
+	Pattern445
+	// Pattern454 quarters have periods of 4, 5 and 4 weeks
+	Pattern454
+	// Pattern544 quarters have periods of 5, 4 and 4 weeks
+	Pattern544
+)
+
+// Returns the number of weeks in each period of a quarter
+func (pattern RetailPattern) weeks() [3]int {
+	switch pattern {
+	case Pattern454:
+		return [3]int{4, 5, 4}
+	case Pattern544:
+		return [3]int{5, 4, 4}
+	default:
+		return [3]int{4, 4, 5}
+	}
+}
+
+// FiscalCalendar describes a fiscal year that starts in a month other than January,
+// or a 52/53 week retail calendar when Pattern is not MonthBased.
+//
+// A retail year ends on the last YearEndWeekday of the month before StartMonth,
+// or on the YearEndWeekday nearest the end of that month when EndNearestWeekday
+// is set. Its week 53, when there is one, belongs to the last period.
+type FiscalCalendar struct {
+	// StartMonth is the month the fiscal year starts in. Zero means January.
+	StartMonth time.Month
+	// NameByStartYear names a fiscal year after the calendar year it starts in
+	// instead of the one it ends in
+	NameByStartYear bool
+	// Pattern selects the weeks per period of a retail calendar
+	Pattern RetailPattern
+	// YearEndWeekday is the weekday a retail year ends on
+	YearEndWeekday time.Weekday
+	// EndNearestWeekday ends a retail year on the weekday nearest the end of the month
+	EndNearestWeekday bool
+}
+
+// NewFiscalCalendar creates a month based fiscal calendar starting in the passed month
+func NewFiscalCalendar(startMonth time.Month) FiscalCalendar {
+	return FiscalCalendar{StartMonth: startMonth}
+}
+
+// NewRetailCalendar creates a 52/53 week retail calendar that starts around the
+// passed month and ends on the last yearEndWeekday of the month before it
+func NewRetailCalendar(startMonth time.Month, yearEndWeekday time.Weekday, pattern RetailPattern) FiscalCalendar {
+	return FiscalCalendar{StartMonth: startMonth, Pattern: pattern, YearEndWeekday: yearEndWeekday}
+}
+
+func (fc FiscalCalendar) startMonth() time.Month {
+	if fc.StartMonth < time.January || fc.StartMonth > time.December {
+		return time.January
+	}
+	return fc.StartMonth
+}
+
+func (fc FiscalCalendar) isRetail() bool {
+	return fc.Pattern != MonthBased
+}
+
+// Returns the calendar year the month based fiscal year starts in
+func (fc FiscalCalendar) startCalendarYear(fiscalYear int) int {
+	if fc.startMonth() == time.January || fc.NameByStartYear {
+		return fiscalYear
+	}
+	return fiscalYear - 1
+}
+
+// Returns the last day of the retail fiscal year
+func (fc FiscalCalendar) retailYearEnd(fiscalYear int, loc *time.Location) time.Time {
+	// day 0 of the start month of the next fiscal year is the last day of this one
+	lastDay := time.Date(fc.startCalendarYear(fiscalYear)+1, fc.startMonth(), 0, 0, 0, 0, 0, loc)
+
+	daysBack := (int(lastDay.Weekday()) - int(fc.YearEndWeekday) + 7) % 7
+	if fc.EndNearestWeekday && daysBack > 3 {
+		daysBack -= 7
+	}
+	return lastDay.AddDate(0, 0, -daysBack)
+}
+
+// Returns the first day of the fiscal year in the passed location
+func (fc FiscalCalendar) yearStart(fiscalYear int, loc *time.Location) time.Time {
+	if fc.isRetail() {
+		return fc.retailYearEnd(fiscalYear-1, loc).AddDate(0, 0, 1)
+	}
+	return time.Date(fc.startCalendarYear(fiscalYear), fc.startMonth(), 1, 0, 0, 0, 0, loc)
+}
+
+// Returns the first day of the period, period 13 being the start of the next fiscal year
+func (fc FiscalCalendar) periodStart(fiscalYear, period int, loc *time.Location) time.Time {
+	if period > 12 {
+		return fc.yearStart(fiscalYear+1, loc)
+	}
+
+	start := fc.yearStart(fiscalYear, loc)
+	if !fc.isRetail() {
+		return start.AddDate(0, period-1, 0)
+	}
+
+	weeks := 0
+	pattern := fc.Pattern.weeks()
+	for i := 0; i < period-1; i++ {
+		weeks += pattern[i%3]
+	}
+	return start.AddDate(0, 0, 7*weeks)
+}
+
+// Year returns the fiscal year the date belongs to
+func (fc FiscalCalendar) Year(date time.Time) int {
+	calendarYear := date.Year()
+	if date.Month() < fc.startMonth() {
+		calendarYear--
+	}
+
+	fiscalYear := calendarYear
+	if fc.startMonth() != time.January && !fc.NameByStartYear {
+		fiscalYear++
+	}
+
+	if fc.isRetail() {
+		day := StartOfDay(date)
+		if day.Before(fc.yearStart(fiscalYear, date.Location())) {
+			fiscalYear--
+		} else if !day.Before(fc.yearStart(fiscalYear+1, date.Location())) {
+			fiscalYear++
+		}
+	}
+
+	return fiscalYear
+}
+
+// Week returns the week of the fiscal year the date falls in, counting from 1
+func (fc FiscalCalendar) Week(date time.Time) int {
+	start := fc.yearStart(fc.Year(date), date.Location())
+	return DifferenceInDays(date, start)/7 + 1
+}
+
+// Period returns the period of the fiscal year the date falls in, from 1 to 12.
+// Periods of month based calendars are fiscal months.
+func (fc FiscalCalendar) Period(date time.Time) int {
+	if !fc.isRetail() {
+		return (int(date.Month())-int(fc.startMonth())+12)%12 + 1
+	}
+
+	week := fc.Week(date)
+	pattern := fc.Pattern.weeks()
+	period := 1
+	weeks := pattern[0]
+	for week > weeks && period < 12 {
+		weeks += pattern[period%3]
+		period++
+	}
+	return period
+}
+
+// Quarter returns the quarter of the fiscal year the date falls in, from 1 to 4
+func (fc FiscalCalendar) Quarter(date time.Time) int {
+	return (fc.Period(date)-1)/3 + 1
+}
+
+// WeeksInYear returns the number of weeks in the fiscal year. Retail years have
+// 52 or 53 weeks, month based years have 52 full weeks and a partial one.
+func (fc FiscalCalendar) WeeksInYear(fiscalYear int) int {
+	days := DifferenceInDays(fc.yearStart(fiscalYear+1, time.UTC), fc.yearStart(fiscalYear, time.UTC))
+	return (days + 6) / 7
+}
+
+// StartOfYear returns the start of the first day of the fiscal year of the date
+func (fc FiscalCalendar) StartOfYear(date time.Time) time.Time {
+	return fc.yearStart(fc.Year(date), date.Location())
+}
+
+// EndOfYear returns the end of the last day of the fiscal year of the date
+func (fc FiscalCalendar) EndOfYear(date time.Time) time.Time {
+	return EndOfDay(fc.yearStart(fc.Year(date)+1, date.Location()).AddDate(0, 0, -1))
+}
+
+// StartOfQuarter returns the start of the first day of the fiscal quarter of the date
+func (fc FiscalCalendar) StartOfQuarter(date time.Time) time.Time {
+	return fc.periodStart(fc.Year(date), fc.Quarter(date)*3-2, date.Location())
+}
+
+// EndOfQuarter returns the end of the last day of the fiscal quarter of the date
+func (fc FiscalCalendar) EndOfQuarter(date time.Time) time.Time {
+	nextQuarter := fc.periodStart(fc.Year(date), fc.Quarter(date)*3+1, date.Location())
+	return EndOfDay(nextQuarter.AddDate(0, 0, -1))
+}
+
+// StartOfPeriod returns the start of the first day of the fiscal period of the date
+func (fc FiscalCalendar) StartOfPeriod(date time.Time) time.Time {
+	return fc.periodStart(fc.Year(date), fc.Period(date), date.Location())
+}
+
+// EndOfPeriod returns the end of the last day of the fiscal period of the date
+func (fc FiscalCalendar) EndOfPeriod(date time.Time) time.Time {
+	nextPeriod := fc.periodStart(fc.Year(date), fc.Period(date)+1, date.Location())
+	return EndOfDay(nextPeriod.AddDate(0, 0, -1))
+}
+
+// IsSameYear checks if both dates fall in the same fiscal year
+func (fc FiscalCalendar) IsSameYear(dateOne, dateTwo time.Time) bool {
+	return fc.Year(dateOne) == fc.Year(dateTwo)
+}
+
+// IsSameQuarter checks if both dates fall in the same quarter of the same fiscal year
+func (fc FiscalCalendar) IsSameQuarter(dateOne, dateTwo time.Time) bool {
+	return fc.IsSameYear(dateOne, dateTwo) && fc.Quarter(dateOne) == fc.Quarter(dateTwo)
+}
+
+// IsSamePeriod checks if both dates fall in the same period of the same fiscal year
+func (fc FiscalCalendar) IsSamePeriod(dateOne, dateTwo time.Time) bool {
+	return fc.IsSameYear(dateOne, dateTwo) && fc.Period(dateOne) == fc.Period(dateTwo)
+}
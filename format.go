@@ -0,0 +1,122 @@
+package thl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**************************
+ *** Formatting Helpers ***
+ **************************/
+
+// The patterns Format understands, keyed by the run of letters they are written as
+var formatTokens = map[string]func(date time.Time) string{
+	"y":    func(date time.Time) string { return strconv.Itoa(date.Year()) },
+	"yy":   func(date time.Time) string { return fmt.Sprintf("%02d", date.Year()%100) },
+	"yyyy": func(date time.Time) string { return fmt.Sprintf("%04d", date.Year()) },
+	"Q":    func(date time.Time) string { return strconv.Itoa(GetQuarter(date)) },
+	"M":    func(date time.Time) string { return strconv.Itoa(int(date.Month())) },
+	"MM":   func(date time.Time) string { return fmt.Sprintf("%02d", date.Month()) },
+	"MMM":  func(date time.Time) string { return date.Month().String()[:3] },
+	"MMMM": func(date time.Time) string { return date.Month().String() },
+	"d":    func(date time.Time) string { return strconv.Itoa(date.Day()) },
+	"dd":   func(date time.Time) string { return fmt.Sprintf("%02d", date.Day()) },
+	"EEE":  func(date time.Time) string { return date.Weekday().String()[:3] },
+	"EEEE": func(date time.Time) string { return date.Weekday().String() },
+	"H":    func(date time.Time) string { return strconv.Itoa(date.Hour()) },
+	"HH":   func(date time.Time) string { return fmt.Sprintf("%02d", date.Hour()) },
+	"h":    func(date time.Time) string { return strconv.Itoa(hourOfHalfDay(date)) },
+	"hh":   func(date time.Time) string { return fmt.Sprintf("%02d", hourOfHalfDay(date)) },
+	"a":    func(date time.Time) string { return date.Format("PM") },
+	"m":    func(date time.Time) string { return strconv.Itoa(date.Minute()) },
+	"mm":   func(date time.Time) string { return fmt.Sprintf("%02d", date.Minute()) },
+	"s":    func(date time.Time) string { return strconv.Itoa(date.Second()) },
+	"ss":   func(date time.Time) string { return fmt.Sprintf("%02d", date.Second()) },
+	"SSS":  func(date time.Time) string { return fmt.Sprintf("%03d", GetMilliseconds(date)) },
+	"z":    func(date time.Time) string { return date.Format("MST") },
+	"Z":    func(date time.Time) string { return date.Format("-0700") },
+	"XXX":  func(date time.Time) string { return date.Format("Z07:00") },
+	"G":    japaneseEraToken(func(era JapaneseEra, year int) string { return era.Abbreviation }),
+	"GG":   japaneseEraToken(func(era JapaneseEra, year int) string { return era.Name }),
+	"GGGG": japaneseEraToken(func(era JapaneseEra, year int) string { return era.Kanji }),
+	"n":    japaneseEraToken(func(era JapaneseEra, year int) string { return strconv.Itoa(year) }),
+	"nn":   japaneseEraToken(paddedEraYear),
+	"r":    func(date time.Time) string { return strconv.Itoa(ToROCYear(date)) },
+	"b":    func(date time.Time) string { return strconv.Itoa(ToThaiBuddhistYear(date)) },
+}
+
+// Returns the hour on a 12 hour clock
+func hourOfHalfDay(date time.Time) int {
+	if hour := date.Hour() % 12; hour != 0 {
+		return hour
+	}
+	return 12
+}
+
+func isPatternLetter(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
+
+// Format writes the date using a pattern of letters like "yyyy-MM-dd HH:mm"
+// instead of the reference date of time.Format. The letters are close to
+// Unicode date patterns but are not the same, G, n, r and b in particular
+// mean something else here. The supported runs of letters are:
+//
+//	y yy yyyy       year
+//	Q               quarter
+//	M MM MMM MMMM   month: 1, 01, Jan, January
+//	d dd            day of the month
+//	EEE EEEE        weekday: Mon, Monday
+//	H HH h hh a     hour of the day or of a 12 hour clock, AM or PM
+//	m mm s ss SSS   minute, second, millisecond
+//	z Z XXX         zone: MST, -0700, -07:00 or Z
+//	G GG GGGG       Japanese era: R, Reiwa, 令和
+//	n nn            year of the Japanese era
+//	r               year of the Republic of China calendar
+//	b               year of the Thai Buddhist Era
+//
+// Text in single quotes is copied as it is, two single quotes write one.
+// Other runs of letters are copied as they are as well.
+func Format(date time.Time, pattern string) string {
+	var builder strings.Builder
+	for i := 0; i < len(pattern); {
+		char := pattern[i]
+		switch {
+		case char == '\'':
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				builder.WriteByte('\'')
+				i += 2
+				continue
+			}
+
+			for i++; i < len(pattern); i++ {
+				if pattern[i] != '\'' {
+					builder.WriteByte(pattern[i])
+				} else if i+1 < len(pattern) && pattern[i+1] == '\'' {
+					builder.WriteByte('\'')
+					i++
+				} else {
+					i++
+					break
+				}
+			}
+		case isPatternLetter(char):
+			end := i + 1
+			for end < len(pattern) && pattern[end] == char {
+				end++
+			}
+			if token, ok := formatTokens[pattern[i:end]]; ok {
+				builder.WriteString(token(date))
+			} else {
+				builder.WriteString(pattern[i:end])
+			}
+			i = end
+		default:
+			builder.WriteByte(char)
+			i++
+		}
+	}
+	return builder.String()
+}
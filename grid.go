@@ -0,0 +1,80 @@
+package thl
+
+import (
+	"time"
+)
+
+/****************************
+ *** Calendar Grid Helper ***
+ ****************************/
+
+// Number of weeks in a grid fitting any month
+const monthGridWeeks = 6
+
+// MonthGridOptions configures the grid MonthGrid returns
+type MonthGridOptions struct {
+	// WeekStart is the weekday of the first column
+	WeekStart time.Weekday
+	// Minimal keeps only the weeks the month touches instead of always 6
+	Minimal bool
+	// Today is the day flagged as today. The zero date means time.Now().
+	Today time.Time
+	// IsHoliday reports if the day at midnight is a holiday. Nil means no holidays.
+	IsHoliday func(day time.Time) bool
+}
+
+// GridDay is a cell of a calendar grid
+type GridDay struct {
+	// Date is the start of the day in the location of the grid
+	Date         time.Time
+	IsToday      bool
+	IsWeekend    bool
+	IsOtherMonth bool
+	IsHoliday    bool
+}
+
+// GridWeek is a row of a calendar grid
+type GridWeek struct {
+	// WeekNumber is the ISO week number of the Thursday of the row, which is
+	// the ISO week of the whole row when weeks start on Monday
+	WeekNumber int
+	Days       [7]GridDay
+}
+
+// MonthGrid returns the weeks of the month of the date as rows of 7 days, for
+// rendering a calendar in the location of the date. The first and last rows
+// include the days of the previous and next months to fill them up. There are
+// always 6 rows, so grids of all months have the same height, or only the
+// rows the month touches when Minimal is set.
+func MonthGrid(date time.Time, opts MonthGridOptions) []GridWeek {
+	today := opts.Today
+	if today.IsZero() {
+		today = time.Now()
+	}
+	today = today.In(date.Location())
+
+	firstDay := StartOfWeekOn(StartOfMonth(date), opts.WeekStart)
+	weekCount := monthGridWeeks
+	if opts.Minimal {
+		lastDay := EndOfWeekOn(EndOfMonth(date), opts.WeekStart)
+		weekCount = int(calendarDate(lastDay).Sub(calendarDate(firstDay))/(24*time.Hour))/7 + 1
+	}
+
+	weeks := make([]GridWeek, weekCount)
+	for week := range weeks {
+		for weekday := range weeks[week].Days {
+			day := addUnits(firstDay, UnitDay, 7*week+weekday)
+			weeks[week].Days[weekday] = GridDay{
+				Date:         day,
+				IsToday:      IsSameDay(day, today),
+				IsWeekend:    IsWeekend(day),
+				IsOtherMonth: day.Month() != date.Month(),
+				IsHoliday:    opts.IsHoliday != nil && opts.IsHoliday(day),
+			}
+			if day.Weekday() == time.Thursday {
+				_, weeks[week].WeekNumber = day.ISOWeek()
+			}
+		}
+	}
+	return weeks
+}
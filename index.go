@@ -0,0 +1,128 @@
+package thl
+
+import (
+	"sort"
+	"time"
+)
+
+/*************************
+ *** Sorted Time Index ***
+ *************************/
+
+// TimeIndex answers nearest and range queries over a fixed set of dates in
+// logarithmic time. Indexes returned by its methods refer to the dates in
+// chronological order, see At.
+type TimeIndex struct {
+	dates []time.Time
+}
+
+// NewTimeIndex builds an index from a copy of the passed dates
+func NewTimeIndex(datesSlice []time.Time) *TimeIndex {
+	dates := append([]time.Time(nil), datesSlice...)
+	sort.Stable(timeSort(dates))
+	return &TimeIndex{dates: dates}
+}
+
+// Len returns the number of dates in the index
+func (ti *TimeIndex) Len() int {
+	return len(ti.dates)
+}
+
+// At returns the date at the position in chronological order
+func (ti *TimeIndex) At(index int) time.Time {
+	return ti.dates[index]
+}
+
+// Returns the position of the first date that is not before the passed one
+func (ti *TimeIndex) lowerBound(date time.Time) int {
+	return sort.Search(len(ti.dates), func(i int) bool {
+		return !ti.dates[i].Before(date)
+	})
+}
+
+// Returns the position of the first date that is after the passed one
+func (ti *TimeIndex) upperBound(date time.Time) int {
+	return sort.Search(len(ti.dates), func(i int) bool {
+		return ti.dates[i].After(date)
+	})
+}
+
+// Returns the absolute distance between the dates as seconds and nanoseconds,
+// which unlike time.Duration does not saturate for dates centuries apart
+func distance(first, second time.Time) (int64, int) {
+	if first.Before(second) {
+		first, second = second, first
+	}
+
+	seconds := first.Unix() - second.Unix()
+	nanos := first.Nanosecond() - second.Nanosecond()
+	if nanos < 0 {
+		seconds--
+		nanos += int(time.Second)
+	}
+	return seconds, nanos
+}
+
+// Closest returns the position of the date closest to the passed one, the
+// earlier date on ties
+func (ti *TimeIndex) Closest(date time.Time) (int, error) {
+	if len(ti.dates) == 0 {
+		return 0, ErrEmptyIndex
+	}
+
+	ceiling := ti.lowerBound(date)
+	if ceiling == 0 {
+		return 0, nil
+	}
+
+	if ceiling == len(ti.dates) {
+		return ceiling - 1, nil
+	}
+
+	floorSeconds, floorNanos := distance(date, ti.dates[ceiling-1])
+	ceilingSeconds, ceilingNanos := distance(date, ti.dates[ceiling])
+	if ceilingSeconds < floorSeconds || (ceilingSeconds == floorSeconds && ceilingNanos < floorNanos) {
+		return ceiling, nil
+	}
+	return ceiling - 1, nil
+}
+
+// Floor returns the position of the latest date that is before or equal to the passed one
+func (ti *TimeIndex) Floor(date time.Time) (int, error) {
+	floor := ti.upperBound(date) - 1
+	if floor < 0 {
+		return 0, ErrNoFloor
+	}
+	return floor, nil
+}
+
+// Ceiling returns the position of the earliest date that is after or equal to the passed one
+func (ti *TimeIndex) Ceiling(date time.Time) (int, error) {
+	ceiling := ti.lowerBound(date)
+	if ceiling == len(ti.dates) {
+		return 0, ErrNoCeiling
+	}
+	return ceiling, nil
+}
+
+// RangeQuery returns the dates from the start date up to but excluding the end date
+func (ti *TimeIndex) RangeQuery(startDate, endDate time.Time) []time.Time {
+	from, to := ti.lowerBound(startDate), ti.lowerBound(endDate)
+	if to <= from {
+		return nil
+	}
+	return append([]time.Time(nil), ti.dates[from:to]...)
+}
+
+// CountBetween returns the number of dates from the start date up to but excluding the end date
+func (ti *TimeIndex) CountBetween(startDate, endDate time.Time) int {
+	if count := ti.lowerBound(endDate) - ti.lowerBound(startDate); count > 0 {
+		return count
+	}
+	return 0
+}
+
+// Rank returns the number of dates before the passed one
+func (ti *TimeIndex) Rank(date time.Time) int {
+	return ti.lowerBound(date)
+}
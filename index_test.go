@@ -0,0 +1,32 @@
+package thl
+
+import (
+	"testing"
+	"time"
+)
+
+func benchmarkDates(count int) []time.Time {
+	dates := make([]time.Time, count)
+	for i := range dates {
+		dates[i] = AddMinutes(first, 7*i)
+	}
+	return dates
+}
+
+func BenchmarkClosestIndexTo(b *testing.B) {
+	dates := benchmarkDates(10000)
+	target := AddMinutes(first, 7*5000+3)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ClosestIndexTo(target, dates)
+	}
+}
+
+func BenchmarkTimeIndexClosest(b *testing.B) {
+	index := NewTimeIndex(benchmarkDates(10000))
+	target := AddMinutes(first, 7*5000+3)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.Closest(target)
+	}
+}
@@ -0,0 +1,319 @@
+package thl
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+/*********************
+ *** Interval Tree ***
+ *********************/
+
+// TimeRange is the half-open range of time from Start up to but excluding End
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Overlaps checks if both ranges share at least one instant. Ranges that only
+// touch, one ending where the other starts, do not overlap.
+func (r TimeRange) Overlaps(other TimeRange) bool {
+	return r.Start.Before(other.End) && other.Start.Before(r.End)
+}
+
+// Contains checks if the date is within the range
+func (r TimeRange) Contains(date time.Time) bool {
+	return !date.Before(r.Start) && date.Before(r.End)
+}
+
+// Duration returns the length of the range
+func (r TimeRange) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// IntervalEntry is a range stored in an IntervalTree with its payload
+type IntervalEntry[T any] struct {
+	Range TimeRange
+	Value T
+}
+
+// IntervalTree stores time ranges with an attached payload and finds the ones
+// overlapping a query in O(log n + k). It is a balanced binary search tree
+// ordered by range start where every node knows the latest end in its subtree.
+// It is safe for concurrent use, queries can run in parallel with each other.
+type IntervalTree[T any] struct {
+	mutex sync.RWMutex
+	root  *intervalNode[T]
+	size  int
+	// insertion counter keeping entries with equal ranges in insertion order
+	sequence uint64
+}
+
+type intervalNode[T any] struct {
+	entry    IntervalEntry[T]
+	sequence uint64
+	maxEnd   time.Time
+	height   int
+	left     *intervalNode[T]
+	right    *intervalNode[T]
+}
+
+// NewIntervalTree creates an empty interval tree
+func NewIntervalTree[T any]() *IntervalTree[T] {
+	return &IntervalTree[T]{}
+}
+
+// Len returns the number of ranges in the tree
+func (tree *IntervalTree[T]) Len() int {
+	tree.mutex.RLock()
+	defer tree.mutex.RUnlock()
+	return tree.size
+}
+
+// Insert adds the range with its payload to the tree
+func (tree *IntervalTree[T]) Insert(r TimeRange, value T) error {
+	if r.End.Before(r.Start) {
+		return fmt.Errorf("%w. Range was not inserted.", ErrEndBeforeStart)
+	}
+
+	tree.mutex.Lock()
+	defer tree.mutex.Unlock()
+
+	tree.sequence++
+	node := &intervalNode[T]{
+		entry:    IntervalEntry[T]{Range: r, Value: value},
+		sequence: tree.sequence,
+		maxEnd:   r.End,
+		height:   1,
+	}
+	tree.root = insertIntervalNode(tree.root, node)
+	tree.size++
+	return nil
+}
+
+// Delete removes the earliest inserted entry with the exact range whose payload
+// the match function accepts, any payload when it is nil. It reports whether
+// an entry was removed.
+func (tree *IntervalTree[T]) Delete(r TimeRange, match func(T) bool) bool {
+	if match == nil {
+		match = func(T) bool { return true }
+	}
+
+	tree.mutex.Lock()
+	defer tree.mutex.Unlock()
+
+	var deleted bool
+	tree.root, deleted = deleteIntervalNode(tree.root, r, match)
+	if deleted {
+		tree.size--
+	}
+	return deleted
+}
+
+// Overlapping returns the entries overlapping the query ordered by range start
+func (tree *IntervalTree[T]) Overlapping(query TimeRange) []IntervalEntry[T] {
+	tree.mutex.RLock()
+	defer tree.mutex.RUnlock()
+
+	var found []IntervalEntry[T]
+	visitOverlapping(tree.root, query, func(entry IntervalEntry[T]) bool {
+		found = append(found, entry)
+		return true
+	})
+	return found
+}
+
+// Stabbing returns the entries containing the date ordered by range start
+func (tree *IntervalTree[T]) Stabbing(date time.Time) []IntervalEntry[T] {
+	return tree.Overlapping(TimeRange{Start: date, End: date.Add(time.Nanosecond)})
+}
+
+// AnyOverlap checks if any entry overlaps the query
+func (tree *IntervalTree[T]) AnyOverlap(query TimeRange) bool {
+	tree.mutex.RLock()
+	defer tree.mutex.RUnlock()
+
+	found := false
+	visitOverlapping(tree.root, query, func(IntervalEntry[T]) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// Calls visit for every node overlapping the query in order until it returns false
+func visitOverlapping[T any](node *intervalNode[T], query TimeRange, visit func(IntervalEntry[T]) bool) bool {
+	// nothing in this subtree ends after the query starts
+	if node == nil || !node.maxEnd.After(query.Start) {
+		return true
+	}
+
+	if !visitOverlapping(node.left, query, visit) {
+		return false
+	}
+
+	// this node and everything to its right start at or after the query end
+	if !node.entry.Range.Start.Before(query.End) {
+		return true
+	}
+
+	if node.entry.Range.Overlaps(query) && !visit(node.entry) {
+		return false
+	}
+
+	return visitOverlapping(node.right, query, visit)
+}
+
+// Orders ranges by start then end
+func compareRanges(first, second TimeRange) int {
+	if order := Compare(first.Start, second.Start); order != 0 {
+		return order
+	}
+	return Compare(first.End, second.End)
+}
+
+func compareIntervalNodes[T any](first, second *intervalNode[T]) int {
+	if order := compareRanges(first.entry.Range, second.entry.Range); order != 0 {
+		return order
+	}
+
+	if first.sequence < second.sequence {
+		return -1
+	} else if first.sequence > second.sequence {
+		return 1
+	}
+	return 0
+}
+
+func insertIntervalNode[T any](root, node *intervalNode[T]) *intervalNode[T] {
+	if root == nil {
+		return node
+	}
+
+	if compareIntervalNodes(node, root) < 0 {
+		root.left = insertIntervalNode(root.left, node)
+	} else {
+		root.right = insertIntervalNode(root.right, node)
+	}
+	return rebalanceIntervalNode(root)
+}
+
+func deleteIntervalNode[T any](root *intervalNode[T], r TimeRange, match func(T) bool) (*intervalNode[T], bool) {
+	if root == nil {
+		return nil, false
+	}
+
+	var deleted bool
+	switch order := compareRanges(r, root.entry.Range); {
+	case order < 0:
+		root.left, deleted = deleteIntervalNode(root.left, r, match)
+	case order > 0:
+		root.right, deleted = deleteIntervalNode(root.right, r, match)
+	default:
+		// equal ranges can be on both sides, earlier insertions to the left
+		root.left, deleted = deleteIntervalNode(root.left, r, match)
+		if !deleted && match(root.entry.Value) {
+			return removeIntervalNode(root), true
+		}
+		if !deleted {
+			root.right, deleted = deleteIntervalNode(root.right, r, match)
+		}
+	}
+
+	if !deleted {
+		return root, false
+	}
+	return rebalanceIntervalNode(root), true
+}
+
+// Removes the root of the subtree and returns the new root
+func removeIntervalNode[T any](root *intervalNode[T]) *intervalNode[T] {
+	if root.left == nil {
+		return root.right
+	}
+
+	if root.right == nil {
+		return root.left
+	}
+
+	successor := root.right
+	for successor.left != nil {
+		successor = successor.left
+	}
+
+	successor.right = removeIntervalMin(root.right)
+	successor.left = root.left
+	return rebalanceIntervalNode(successor)
+}
+
+func removeIntervalMin[T any](root *intervalNode[T]) *intervalNode[T] {
+	if root.left == nil {
+		return root.right
+	}
+	root.left = removeIntervalMin(root.left)
+	return rebalanceIntervalNode(root)
+}
+
+func intervalNodeHeight[T any](node *intervalNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+// Recomputes the height and the latest end of the subtree
+func updateIntervalNode[T any](node *intervalNode[T]) {
+	node.height = 1 + intervalNodeHeight(node.left)
+	if rightHeight := intervalNodeHeight(node.right); rightHeight >= node.height {
+		node.height = rightHeight + 1
+	}
+
+	node.maxEnd = node.entry.Range.End
+	if node.left != nil && node.left.maxEnd.After(node.maxEnd) {
+		node.maxEnd = node.left.maxEnd
+	}
+	if node.right != nil && node.right.maxEnd.After(node.maxEnd) {
+		node.maxEnd = node.right.maxEnd
+	}
+}
+
+func rotateIntervalLeft[T any](node *intervalNode[T]) *intervalNode[T] {
+	pivot := node.right
+	node.right = pivot.left
+	pivot.left = node
+	updateIntervalNode(node)
+	updateIntervalNode(pivot)
+	return pivot
+}
+
+func rotateIntervalRight[T any](node *intervalNode[T]) *intervalNode[T] {
+	pivot := node.left
+	node.left = pivot.right
+	pivot.right = node
+	updateIntervalNode(node)
+	updateIntervalNode(pivot)
+	return pivot
+}
+
+// Restores the AVL balance of the subtree after an insertion or a deletion below it
+func rebalanceIntervalNode[T any](node *intervalNode[T]) *intervalNode[T] {
+	updateIntervalNode(node)
+	balance := intervalNodeHeight(node.left) - intervalNodeHeight(node.right)
+
+	if balance > 1 {
+		if intervalNodeHeight(node.left.left) < intervalNodeHeight(node.left.right) {
+			node.left = rotateIntervalLeft(node.left)
+		}
+		return rotateIntervalRight(node)
+	}
+
+	if balance < -1 {
+		if intervalNodeHeight(node.right.right) < intervalNodeHeight(node.right.left) {
+			node.right = rotateIntervalRight(node.right)
+		}
+		return rotateIntervalLeft(node)
+	}
+
+	return node
+}
@@ -0,0 +1,84 @@
+package thl
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomRange(random *rand.Rand) TimeRange {
+	start := AddHours(first, random.Intn(1000))
+	return TimeRange{Start: start, End: AddHours(start, random.Intn(48))}
+}
+
+func TestIntervalTreeMatchesPairwiseOverlap(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	tree := NewIntervalTree[int]()
+	var stored []TimeRange
+
+	for i := 0; i < 2000; i++ {
+		r := randomRange(random)
+		if random.Intn(4) == 0 && len(stored) > 0 {
+			victim := random.Intn(len(stored))
+			if !tree.Delete(stored[victim], nil) {
+				t.Fatalf("could not delete %v", stored[victim])
+			}
+			stored = append(stored[:victim], stored[victim+1:]...)
+		} else {
+			tree.Insert(r, i)
+			stored = append(stored, r)
+		}
+
+		query := randomRange(random)
+		expected := 0
+		for _, s := range stored {
+			if s.Overlaps(query) {
+				expected++
+			}
+		}
+
+		found := tree.Overlapping(query)
+		if len(found) != expected {
+			t.Fatalf("query %v found %d ranges, expected %d", query, len(found), expected)
+		}
+		if tree.AnyOverlap(query) != (expected > 0) {
+			t.Fatalf("query %v AnyOverlap disagrees with %d overlaps", query, expected)
+		}
+		for j := 1; j < len(found); j++ {
+			if found[j].Range.Start.Before(found[j-1].Range.Start) {
+				t.Fatalf("query %v results are not ordered by start", query)
+			}
+		}
+	}
+
+	if tree.Len() != len(stored) {
+		t.Fatalf("tree holds %d ranges, expected %d", tree.Len(), len(stored))
+	}
+}
+
+func BenchmarkPairwiseOverlap(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	stored := make([]TimeRange, 20000)
+	for i := range stored {
+		stored[i] = randomRange(random)
+	}
+	query := TimeRange{Start: AddHours(first, 500), End: AddHours(first, 502)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, r := range stored {
+			r.Overlaps(query)
+		}
+	}
+}
+
+func BenchmarkIntervalTreeAnyOverlap(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	tree := NewIntervalTree[int]()
+	for i := 0; i < 20000; i++ {
+		tree.Insert(randomRange(random), i)
+	}
+	query := TimeRange{Start: AddHours(first, 500), End: AddHours(first, 502)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.AnyOverlap(query)
+	}
+}
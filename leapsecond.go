@@ -0,0 +1,261 @@
+package thl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/***************************
+ *** Leap Second Helpers ***
+ ***************************/
+
+// TAI is ahead of GPS time by a constant 19 seconds
+const gpsBehindTAI = 19 * time.Second
+
+// The IERS leap second table in the format of the leap-seconds.list file
+// distributed with IERS Bulletin C: NTP seconds at which the offset starts
+// to apply followed by TAI-UTC in seconds, and the NTP second the file expires at.
+const embeddedLeapSeconds = `
+#	File expires on 28 December 2026
+#@	4007404800
+2272060800	10	# 1 Jan 1972
+2287785600	11	# 1 Jul 1972
+2303683200	12	# 1 Jan 1973
+2335219200	13	# 1 Jan 1974
+2366755200	14	# 1 Jan 1975
+2398291200	15	# 1 Jan 1976
+2429913600	16	# 1 Jan 1977
+2461449600	17	# 1 Jan 1978
+2492985600	18	# 1 Jan 1979
+2524521600	19	# 1 Jan 1980
+2571782400	20	# 1 Jul 1981
+2603318400	21	# 1 Jul 1982
+2634854400	22	# 1 Jul 1983
+2698012800	23	# 1 Jul 1985
+2776982400	24	# 1 Jan 1988
+2840140800	25	# 1 Jan 1990
+2871676800	26	# 1 Jan 1991
+2918937600	27	# 1 Jul 1992
+2950473600	28	# 1 Jul 1993
+2982009600	29	# 1 Jul 1994
+3029443200	30	# 1 Jan 1996
+3076704000	31	# 1 Jul 1997
+3124137600	32	# 1 Jan 1999
+3345062400	33	# 1 Jan 2006
+3439756800	34	# 1 Jan 2009
+3550089600	35	# 1 Jul 2012
+3644697600	36	# 1 Jul 2015
+3692217600	37	# 1 Jan 2017
+`
+
+// Internal structure holding the TAI-UTC offset that applies from a UTC instant on
+type leapSecondEntry struct {
+	start  time.Time
+	offset time.Duration
+}
+
+// LeapSecondTable holds the TAI-UTC offsets announced by the IERS.
+// Dates before the first entry use the offset of the first entry.
+type LeapSecondTable struct {
+	entries []leapSecondEntry
+	expires time.Time
+}
+
+var (
+	leapSecondsMutex sync.RWMutex
+	leapSeconds      = mustLoadEmbeddedLeapSeconds()
+)
+
+func mustLoadEmbeddedLeapSeconds() *LeapSecondTable {
+	table, err := LoadLeapSecondTable(strings.NewReader(embeddedLeapSeconds))
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+// LoadLeapSecondTable reads a leap second table in the leap-seconds.list format
+// published by the IERS, including its "#@" expiration line when present
+func LoadLeapSecondTable(r io.Reader) (*LeapSecondTable, error) {
+	table := &LeapSecondTable{}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "#@") {
+			ntpSeconds, err := strconv.ParseInt(strings.TrimSpace(line[2:]), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w, bad expiration on line %d", ErrMalformedLeapSecondTable, lineNumber)
+			}
+			table.expires = time.Unix(ntpSeconds-ntpUnixEpochOffset, 0).UTC()
+			continue
+		}
+
+		if comment := strings.IndexByte(line, '#'); comment >= 0 {
+			line = line[:comment]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w, bad entry on line %d", ErrMalformedLeapSecondTable, lineNumber)
+		}
+
+		ntpSeconds, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w, bad entry on line %d", ErrMalformedLeapSecondTable, lineNumber)
+		}
+
+		offset, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w, bad entry on line %d", ErrMalformedLeapSecondTable, lineNumber)
+		}
+
+		entry := leapSecondEntry{
+			start:  time.Unix(ntpSeconds-ntpUnixEpochOffset, 0).UTC(),
+			offset: time.Duration(offset) * time.Second,
+		}
+
+		if count := len(table.entries); count > 0 && !table.entries[count-1].start.Before(entry.start) {
+			return nil, fmt.Errorf("%w, entry out of chronological order on line %d", ErrMalformedLeapSecondTable, lineNumber)
+		}
+
+		table.entries = append(table.entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(table.entries) == 0 {
+		return nil, fmt.Errorf("%w, no entries", ErrMalformedLeapSecondTable)
+	}
+
+	return table, nil
+}
+
+// SetLeapSecondTable replaces the table used by the package level leap second
+// helpers. Nil restores the table embedded in the package.
+func SetLeapSecondTable(table *LeapSecondTable) {
+	if table == nil {
+		table = mustLoadEmbeddedLeapSeconds()
+	}
+
+	leapSecondsMutex.Lock()
+	defer leapSecondsMutex.Unlock()
+	leapSeconds = table
+}
+
+// CurrentLeapSecondTable returns the table used by the package level leap second helpers
+func CurrentLeapSecondTable() *LeapSecondTable {
+	leapSecondsMutex.RLock()
+	defer leapSecondsMutex.RUnlock()
+	return leapSeconds
+}
+
+// Expires returns the date after which the table may be missing announced leap
+// seconds. It is the zero date when the table did not state an expiration.
+func (table *LeapSecondTable) Expires() time.Time {
+	return table.expires
+}
+
+// Returns TAI-UTC at the UTC instant
+func (table *LeapSecondTable) offsetAt(utc time.Time) time.Duration {
+	for i := len(table.entries) - 1; i >= 0; i-- {
+		if !utc.Before(table.entries[i].start) {
+			return table.entries[i].offset
+		}
+	}
+	return table.entries[0].offset
+}
+
+// UTCToTAI returns the TAI reading at the UTC instant
+func (table *LeapSecondTable) UTCToTAI(utc time.Time) time.Time {
+	return utc.UTC().Add(table.offsetAt(utc))
+}
+
+// TAIToUTC returns the UTC instant of the TAI reading. A reading that falls
+// inside an inserted leap second, which UTC shows as 23:59:60, is returned as
+// a repeated 23:59:59.
+func (table *LeapSecondTable) TAIToUTC(tai time.Time) time.Time {
+	tai = tai.UTC()
+	for i := len(table.entries) - 1; i >= 0; i-- {
+		entry := table.entries[i]
+		if !tai.Before(entry.start.Add(entry.offset)) {
+			return tai.Add(-entry.offset)
+		}
+
+		if i > 0 {
+			leapSecondStart := entry.start.Add(table.entries[i-1].offset)
+			if !tai.Before(leapSecondStart) {
+				return entry.start.Add(-time.Second).Add(tai.Sub(leapSecondStart))
+			}
+		}
+	}
+	return tai.Add(-table.entries[0].offset)
+}
+
+// UTCToGPS returns the GPS time reading at the UTC instant
+func (table *LeapSecondTable) UTCToGPS(utc time.Time) time.Time {
+	return table.UTCToTAI(utc).Add(-gpsBehindTAI)
+}
+
+// GPSToUTC returns the UTC instant of the GPS time reading
+func (table *LeapSecondTable) GPSToUTC(gps time.Time) time.Time {
+	return table.TAIToUTC(gps.Add(gpsBehindTAI))
+}
+
+// LeapSecondsBetween returns the number of leap seconds inserted from the first
+// date up to the second one. It is negative when the second date is earlier.
+func (table *LeapSecondTable) LeapSecondsBetween(dateLeft, dateRight time.Time) int {
+	return int((table.offsetAt(dateRight) - table.offsetAt(dateLeft)) / time.Second)
+}
+
+// DifferenceInSecondsWithLeapSeconds works like DifferenceInSeconds but also
+// counts the leap seconds inserted between the dates
+func (table *LeapSecondTable) DifferenceInSecondsWithLeapSeconds(dateLeft, dateRight time.Time) float64 {
+	return DifferenceInSeconds(dateLeft, dateRight) + float64(table.LeapSecondsBetween(dateRight, dateLeft))
+}
+
+// UTCToTAI returns the TAI reading at the UTC instant using the current leap second table
+func UTCToTAI(utc time.Time) time.Time {
+	return CurrentLeapSecondTable().UTCToTAI(utc)
+}
+
+// TAIToUTC returns the UTC instant of the TAI reading using the current leap second table
+func TAIToUTC(tai time.Time) time.Time {
+	return CurrentLeapSecondTable().TAIToUTC(tai)
+}
+
+// UTCToGPS returns the GPS time reading at the UTC instant using the current leap second table
+func UTCToGPS(utc time.Time) time.Time {
+	return CurrentLeapSecondTable().UTCToGPS(utc)
+}
+
+// GPSToUTC returns the UTC instant of the GPS time reading using the current leap second table
+func GPSToUTC(gps time.Time) time.Time {
+	return CurrentLeapSecondTable().GPSToUTC(gps)
+}
+
+// LeapSecondsBetween returns the number of leap seconds inserted between the
+// dates using the current leap second table
+func LeapSecondsBetween(dateLeft, dateRight time.Time) int {
+	return CurrentLeapSecondTable().LeapSecondsBetween(dateLeft, dateRight)
+}
+
+// DifferenceInSecondsWithLeapSeconds works like DifferenceInSeconds but also
+// counts the leap seconds inserted between the dates using the current leap second table
+func DifferenceInSecondsWithLeapSeconds(dateLeft, dateRight time.Time) float64 {
+	return CurrentLeapSecondTable().DifferenceInSecondsWithLeapSeconds(dateLeft, dateRight)
+}
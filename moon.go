@@ -0,0 +1,276 @@
+package thl
+
+import (
+	"math"
+	"time"
+)
+
+/********************
+ *** Moon Helpers ***
+ ********************/
+
+// Length of the mean synodic month in days and the Julian Ephemeris Day of
+// the first mean new moon of 2000, from Astronomical Algorithms by Jean Meeus
+const (
+	synodicMonth     = 29.530588861
+	meanNewMoon2000  = 2451550.09766
+	terrestrialToTAI = 32184 * time.Millisecond
+)
+
+// MoonPhaseName names the phases of the moon
+type MoonPhaseName int
+
+const (
+	NewMoon MoonPhaseName = iota
+	WaxingCrescent
+	FirstQuarter
+	WaxingGibbous
+	FullMoon
+	WaningGibbous
+	LastQuarter
+	WaningCrescent
+)
+
+func (name MoonPhaseName) String() string {
+	switch name {
+	case NewMoon:
+		return "new moon"
+	case WaxingCrescent:
+		return "waxing crescent"
+	case FirstQuarter:
+		return "first quarter"
+	case WaxingGibbous:
+		return "waxing gibbous"
+	case FullMoon:
+		return "full moon"
+	case WaningGibbous:
+		return "waning gibbous"
+	case LastQuarter:
+		return "last quarter"
+	case WaningCrescent:
+		return "waning crescent"
+	}
+	return "unknown"
+}
+
+// LunarPhase is the phase of the moon at an instant
+type LunarPhase struct {
+	// Fraction is how far the moon is through the lunation, from 0 at the new
+	// moon over 0.5 around the full moon up to 1 at the next new moon
+	Fraction float64
+	// Name is the principal phase when it is within 12 hours, otherwise the
+	// crescent or gibbous phase in between
+	Name MoonPhaseName
+}
+
+// MoonPhaseEvent is the instant of a principal phase of the moon
+type MoonPhaseEvent struct {
+	Phase MoonPhaseName
+	Time  time.Time
+}
+
+// Returns the UTC instant of the Julian Ephemeris Day, which is in terrestrial time
+func fromJulianEphemerisDay(julianEphemerisDay float64) time.Time {
+	terrestrial := FromJulianDate(julianEphemerisDay, time.UTC)
+	return TAIToUTC(terrestrial.Add(-terrestrialToTAI))
+}
+
+// Returns the Julian Ephemeris Day of the UTC instant
+func toJulianEphemerisDay(date time.Time) float64 {
+	return ToJulianDate(UTCToTAI(date).Add(terrestrialToTAI))
+}
+
+func sinDegrees(degrees float64) float64 {
+	return math.Sin(degreesToRadians(degrees))
+}
+
+func cosDegrees(degrees float64) float64 {
+	return math.Cos(degreesToRadians(degrees))
+}
+
+// Returns the instant of the principal phase in the lunation k, counted from
+// the first new moon of 2000, following chapter 49 of Astronomical Algorithms
+func moonPhaseTime(lunation int, phase MoonPhaseName) time.Time {
+	k := float64(lunation) + float64(phase)/8
+	t := k / 1236.85
+	t2, t3, t4 := t*t, t*t*t, t*t*t*t
+
+	jde := meanNewMoon2000 + synodicMonth*k + 0.00015437*t2 - 0.000000150*t3 + 0.00000000073*t4
+	e := 1 - 0.002516*t - 0.0000074*t2
+	m := 2.5534 + 29.10535670*k - 0.0000014*t2 - 0.00000011*t3
+	mp := 201.5643 + 385.81693528*k + 0.0107582*t2 + 0.00001238*t3 - 0.000000058*t4
+	f := 160.7108 + 390.67050284*k - 0.0016118*t2 - 0.00000227*t3 + 0.000000011*t4
+	omega := 124.7746 - 1.56375588*k + 0.0020672*t2 + 0.00000215*t3
+
+	switch phase {
+	case NewMoon, FullMoon:
+		coefficients := [...]float64{-0.40720, 0.17241, 0.01608, 0.01039, 0.00739, -0.00514, 0.00208}
+		if phase == FullMoon {
+			coefficients = [...]float64{-0.40614, 0.17302, 0.01614, 0.01043, 0.00734, -0.00515, 0.00209}
+		}
+		jde += coefficients[0]*sinDegrees(mp) +
+			coefficients[1]*e*sinDegrees(m) +
+			coefficients[2]*sinDegrees(2*mp) +
+			coefficients[3]*sinDegrees(2*f) +
+			coefficients[4]*e*sinDegrees(mp-m) +
+			coefficients[5]*e*sinDegrees(mp+m) +
+			coefficients[6]*e*e*sinDegrees(2*m) -
+			0.00111*sinDegrees(mp-2*f) -
+			0.00057*sinDegrees(mp+2*f) +
+			0.00056*e*sinDegrees(2*mp+m) -
+			0.00042*sinDegrees(3*mp) +
+			0.00042*e*sinDegrees(m+2*f) +
+			0.00038*e*sinDegrees(m-2*f) -
+			0.00024*e*sinDegrees(2*mp-m) -
+			0.00017*sinDegrees(omega) -
+			0.00007*sinDegrees(mp+2*m) +
+			0.00004*sinDegrees(2*mp-2*f) +
+			0.00004*sinDegrees(3*m) +
+			0.00003*sinDegrees(mp+m-2*f) +
+			0.00003*sinDegrees(2*mp+2*f) -
+			0.00003*sinDegrees(mp+m+2*f) +
+			0.00003*sinDegrees(mp-m+2*f) -
+			0.00002*sinDegrees(mp-m-2*f) -
+			0.00002*sinDegrees(3*mp+m) +
+			0.00002*sinDegrees(4*mp)
+	default:
+		jde += -0.62801*sinDegrees(mp) +
+			0.17172*e*sinDegrees(m) -
+			0.01183*e*sinDegrees(mp+m) +
+			0.00862*sinDegrees(2*mp) +
+			0.00804*sinDegrees(2*f) +
+			0.00454*e*sinDegrees(mp-m) +
+			0.00204*e*e*sinDegrees(2*m) -
+			0.00180*sinDegrees(mp-2*f) -
+			0.00070*sinDegrees(mp+2*f) -
+			0.00040*sinDegrees(3*mp) -
+			0.00034*e*sinDegrees(2*mp-m) +
+			0.00032*e*sinDegrees(m+2*f) +
+			0.00032*e*sinDegrees(m-2*f) -
+			0.00028*e*e*sinDegrees(mp+2*m) +
+			0.00027*e*sinDegrees(2*mp+m) -
+			0.00017*sinDegrees(omega) -
+			0.00005*sinDegrees(mp-m-2*f) +
+			0.00004*sinDegrees(2*mp+2*f) -
+			0.00004*sinDegrees(mp+m+2*f) +
+			0.00004*sinDegrees(mp-2*m) +
+			0.00003*sinDegrees(mp+m-2*f) +
+			0.00003*sinDegrees(3*m) +
+			0.00002*sinDegrees(2*mp-2*f) +
+			0.00002*sinDegrees(mp-m+2*f) -
+			0.00002*sinDegrees(3*mp+m)
+
+		w := 0.00306 - 0.00038*e*cosDegrees(m) + 0.00026*cosDegrees(mp) -
+			0.00002*cosDegrees(mp-m) + 0.00002*cosDegrees(mp+m) + 0.00002*cosDegrees(2*f)
+		if phase == FirstQuarter {
+			jde += w
+		} else {
+			jde -= w
+		}
+	}
+
+	// corrections for the pull of the planets
+	planetary := [...][3]float64{
+		{0.000325, 299.77, 0.107408}, {0.000165, 251.88, 0.016321}, {0.000164, 251.83, 26.651886},
+		{0.000126, 349.42, 36.412478}, {0.000110, 84.66, 18.206239}, {0.000062, 141.74, 53.303771},
+		{0.000060, 207.14, 2.453732}, {0.000056, 154.84, 7.306860}, {0.000047, 34.52, 27.261239},
+		{0.000042, 207.19, 0.121824}, {0.000040, 291.34, 1.844379}, {0.000037, 161.72, 24.198154},
+		{0.000035, 239.56, 25.513099}, {0.000023, 331.55, 3.592518},
+	}
+	for i, term := range planetary {
+		argument := term[1] + term[2]*k
+		if i == 0 {
+			argument -= 0.009173 * t2
+		}
+		jde += term[0] * sinDegrees(argument)
+	}
+
+	return fromJulianEphemerisDay(jde).Round(time.Second)
+}
+
+// Returns the first instant of the principal phase after the date
+func nextMoonPhase(date time.Time, phase MoonPhaseName) time.Time {
+	lunation := int(math.Floor((toJulianEphemerisDay(date)-meanNewMoon2000)/synodicMonth)) - 1
+	for {
+		if at := moonPhaseTime(lunation, phase); at.After(date) {
+			return at.In(date.Location())
+		}
+		lunation++
+	}
+}
+
+// Returns the last new moon at or before the date
+func previousNewMoon(date time.Time) time.Time {
+	lunation := int(math.Floor((toJulianEphemerisDay(date)-meanNewMoon2000)/synodicMonth)) + 1
+	for {
+		if at := moonPhaseTime(lunation, NewMoon); !at.After(date) {
+			return at
+		}
+		lunation--
+	}
+}
+
+// NextNewMoon returns the first new moon after the date, accurate to about a minute
+func NextNewMoon(date time.Time) time.Time {
+	return nextMoonPhase(date, NewMoon)
+}
+
+// NextFullMoon returns the first full moon after the date, accurate to about a minute
+func NextFullMoon(date time.Time) time.Time {
+	return nextMoonPhase(date, FullMoon)
+}
+
+// MoonPhasesInInterval returns the new moons, first quarters, full moons and
+// last quarters from the start up to but excluding the end, in chronological order
+func MoonPhasesInInterval(startDate, endDate time.Time) []MoonPhaseEvent {
+	var events []MoonPhaseEvent
+	lunation := int(math.Floor((toJulianEphemerisDay(startDate)-meanNewMoon2000)/synodicMonth)) - 1
+	for ; ; lunation++ {
+		for _, phase := range []MoonPhaseName{NewMoon, FirstQuarter, FullMoon, LastQuarter} {
+			at := moonPhaseTime(lunation, phase)
+			if !at.Before(endDate) {
+				return events
+			}
+			if !at.Before(startDate) {
+				events = append(events, MoonPhaseEvent{Phase: phase, Time: at.In(startDate.Location())})
+			}
+		}
+	}
+}
+
+// MoonPhase returns how far the moon is through its lunation at the date and the name of its phase
+func MoonPhase(date time.Time) LunarPhase {
+	previous := previousNewMoon(date)
+	next := NextNewMoon(date)
+	phase := LunarPhase{Fraction: float64(date.Sub(previous)) / float64(next.Sub(previous))}
+
+	for _, event := range MoonPhasesInInterval(previous, next.Add(time.Second)) {
+		if distance := date.Sub(event.Time); distance < 12*time.Hour && distance > -12*time.Hour {
+			phase.Name = event.Phase
+			return phase
+		}
+		if event.Time.After(date) {
+			// the crescent or gibbous phase before the principal one
+			phase.Name = (event.Phase + WaningCrescent) % (WaningCrescent + 1)
+			return phase
+		}
+	}
+	return phase
+}
+
+// MoonIllumination returns the illuminated fraction of the disk of the moon
+// at the date, from 0 at the new moon up to 1 at the full moon
+func MoonIllumination(date time.Time) float64 {
+	t := (toJulianEphemerisDay(date) - julianDateJ2000) / 36525
+	t2, t3, t4 := t*t, t*t*t, t*t*t*t
+
+	// mean elongation of the moon and mean anomalies of the sun and the moon
+	d := 297.8501921 + 445267.1114034*t - 0.0018819*t2 + t3/545868 - t4/113065000
+	m := 357.5291092 + 35999.0502909*t - 0.0001536*t2 + t3/24490000
+	mp := 134.9633964 + 477198.8675055*t + 0.0087414*t2 + t3/69699 - t4/14712000
+
+	phaseAngle := 180 - d - 6.289*sinDegrees(mp) + 2.100*sinDegrees(m) -
+		1.274*sinDegrees(2*d-mp) - 0.658*sinDegrees(2*d) -
+		0.214*sinDegrees(2*mp) - 0.110*sinDegrees(d)
+	return (1 + cosDegrees(phaseAngle)) / 2
+}
@@ -0,0 +1,92 @@
+package thl
+
+import (
+	"testing"
+	"time"
+)
+
+// Principal phases of the moon in 2024 in UTC, rounded to the minute, as
+// published by the Astronomical Applications Department of the US Naval Observatory
+var publishedMoonPhases2024 = []struct {
+	phase MoonPhaseName
+	at    string
+}{
+	{LastQuarter, "2024-01-04 03:30"}, {NewMoon, "2024-01-11 11:57"}, {FirstQuarter, "2024-01-18 03:52"}, {FullMoon, "2024-01-25 17:54"},
+	{LastQuarter, "2024-02-02 23:18"}, {NewMoon, "2024-02-09 22:59"}, {FirstQuarter, "2024-02-16 15:01"}, {FullMoon, "2024-02-24 12:30"},
+	{LastQuarter, "2024-03-03 15:23"}, {NewMoon, "2024-03-10 09:00"}, {FirstQuarter, "2024-03-17 04:11"}, {FullMoon, "2024-03-25 07:00"},
+	{LastQuarter, "2024-04-02 03:15"}, {NewMoon, "2024-04-08 18:21"}, {FirstQuarter, "2024-04-15 19:13"}, {FullMoon, "2024-04-23 23:49"},
+	{LastQuarter, "2024-05-01 11:27"}, {NewMoon, "2024-05-08 03:22"}, {FirstQuarter, "2024-05-15 11:48"}, {FullMoon, "2024-05-23 13:53"},
+	{LastQuarter, "2024-05-30 17:13"}, {NewMoon, "2024-06-06 12:38"}, {FirstQuarter, "2024-06-14 05:18"}, {FullMoon, "2024-06-22 01:08"},
+	{LastQuarter, "2024-06-28 21:53"}, {NewMoon, "2024-07-05 22:57"}, {FirstQuarter, "2024-07-13 22:49"}, {FullMoon, "2024-07-21 10:17"},
+	{LastQuarter, "2024-07-28 02:51"}, {NewMoon, "2024-08-04 11:13"}, {FirstQuarter, "2024-08-12 15:19"}, {FullMoon, "2024-08-19 18:26"},
+	{LastQuarter, "2024-08-26 09:26"}, {NewMoon, "2024-09-03 01:55"}, {FirstQuarter, "2024-09-11 06:06"}, {FullMoon, "2024-09-18 02:34"},
+	{LastQuarter, "2024-09-24 18:50"}, {NewMoon, "2024-10-02 18:49"}, {FirstQuarter, "2024-10-10 18:55"}, {FullMoon, "2024-10-17 11:26"},
+	{LastQuarter, "2024-10-24 08:03"}, {NewMoon, "2024-11-01 12:47"}, {FirstQuarter, "2024-11-09 05:55"}, {FullMoon, "2024-11-15 21:28"},
+	{LastQuarter, "2024-11-23 01:28"}, {NewMoon, "2024-12-01 06:21"}, {FirstQuarter, "2024-12-08 15:27"}, {FullMoon, "2024-12-15 09:02"},
+	{LastQuarter, "2024-12-22 22:18"}, {NewMoon, "2024-12-30 22:27"},
+}
+
+func withinMinutes(left, right time.Time, minutes int) bool {
+	difference := left.Sub(right)
+	return difference <= time.Duration(minutes)*time.Minute && difference >= -time.Duration(minutes)*time.Minute
+}
+
+func TestMoonPhasesMatchPublishedTable(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := MoonPhasesInInterval(start, start.AddDate(1, 0, 0))
+	if len(events) != len(publishedMoonPhases2024) {
+		t.Fatalf("found %d phases, expected %d", len(events), len(publishedMoonPhases2024))
+	}
+
+	for i, published := range publishedMoonPhases2024 {
+		expected, _ := time.Parse("2006-01-02 15:04", published.at)
+		event := events[i]
+		if event.Phase != published.phase || !withinMinutes(event.Time, expected, 2) {
+			t.Errorf("found %v at %v, expected %v at %v", event.Phase, event.Time, published.phase, expected)
+		}
+
+		before := expected.Add(-3 * time.Hour)
+		switch published.phase {
+		case NewMoon:
+			if next := NextNewMoon(before); !next.Equal(event.Time) {
+				t.Errorf("next new moon after %v is %v, expected %v", before, next, event.Time)
+			}
+			if illumination := MoonIllumination(expected); illumination > 0.01 {
+				t.Errorf("illumination at the new moon %v is %v", expected, illumination)
+			}
+		case FullMoon:
+			if next := NextFullMoon(before); !next.Equal(event.Time) {
+				t.Errorf("next full moon after %v is %v, expected %v", before, next, event.Time)
+			}
+			if illumination := MoonIllumination(expected); illumination < 0.99 {
+				t.Errorf("illumination at the full moon %v is %v", expected, illumination)
+			}
+		}
+
+		if phase := MoonPhase(before); phase.Name != published.phase {
+			t.Errorf("phase at %v is %v, expected %v", before, phase.Name, published.phase)
+		}
+	}
+}
+
+func TestMoonPhaseFractionIncreasesWithinLunation(t *testing.T) {
+	previous := MoonPhase(time.Date(2024, 1, 11, 12, 0, 0, 0, time.UTC))
+	for hour := 1; hour < 29*24; hour++ {
+		phase := MoonPhase(time.Date(2024, 1, 11, 12+hour, 0, 0, 0, time.UTC))
+		// within 12 hours of the next new moon the name wraps around to it
+		wrapped := phase.Name == NewMoon && phase.Fraction > 0.95
+		if phase.Fraction <= previous.Fraction || phase.Fraction >= 1 || (phase.Name < previous.Name && !wrapped) {
+			t.Fatalf("phase %+v after %+v", phase, previous)
+		}
+		previous = phase
+	}
+}
+
+// Example 49.a of Astronomical Algorithms by Jean Meeus: the new moon of
+// February 1977 at 3:37:40 in terrestrial time, 48 seconds ahead of UTC
+func TestNewMoonMatchesMeeus(t *testing.T) {
+	expected := time.Date(1977, 2, 18, 3, 36, 52, 0, time.UTC)
+	if next := NextNewMoon(time.Date(1977, 2, 10, 0, 0, 0, 0, time.UTC)); !withinMinutes(next, expected, 1) {
+		t.Errorf("found %v, expected %v", next, expected)
+	}
+}
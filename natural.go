@@ -0,0 +1,416 @@
+package thl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*******************************
+ *** Natural Language Parser ***
+ *******************************/
+
+// NaturalDate is what ParseNatural resolves an input to: an instant, like
+// "in 3 days", or an interval, like the whole day for "tomorrow"
+type NaturalDate struct {
+	Start time.Time
+	End   time.Time
+}
+
+// IsInstant reports whether the input named a single instant, Start and End being equal
+func (d NaturalDate) IsInstant() bool {
+	return d.Start.Equal(d.End)
+}
+
+// Range returns the interval named by the input, empty for instants
+func (d NaturalDate) Range() TimeRange {
+	return TimeRange{Start: d.Start, End: d.End}
+}
+
+// NaturalLocale holds the keywords ParseNatural understands. Keywords may
+// span several words, like "first day of", and are matched case-insensitively.
+type NaturalLocale struct {
+	Now       string
+	Today     string
+	Tomorrow  string
+	Yesterday string
+	Next      string
+	Last      string
+	This      string
+	In        string
+	Ago       string
+	At        string
+	FirstDay  string
+	LastDay   string
+	Noon      string
+	Midnight  string
+	AM        string
+	PM        string
+	Weekdays  map[string]time.Weekday
+	Units     map[string]Unit
+	Numbers   map[string]int
+}
+
+// EnglishLocale is the locale ParseNatural uses when none is passed
+var EnglishLocale = &NaturalLocale{
+	Now:       "now",
+	Today:     "today",
+	Tomorrow:  "tomorrow",
+	Yesterday: "yesterday",
+	Next:      "next",
+	Last:      "last",
+	This:      "this",
+	In:        "in",
+	Ago:       "ago",
+	At:        "at",
+	FirstDay:  "first day of",
+	LastDay:   "last day of",
+	Noon:      "noon",
+	Midnight:  "midnight",
+	AM:        "am",
+	PM:        "pm",
+	Weekdays: map[string]time.Weekday{
+		"sunday":    time.Sunday,
+		"monday":    time.Monday,
+		"tuesday":   time.Tuesday,
+		"wednesday": time.Wednesday,
+		"thursday":  time.Thursday,
+		"friday":    time.Friday,
+		"saturday":  time.Saturday,
+	},
+	Units: map[string]Unit{
+		"second": UnitSecond, "seconds": UnitSecond,
+		"minute": UnitMinute, "minutes": UnitMinute,
+		"hour": UnitHour, "hours": UnitHour,
+		"day": UnitDay, "days": UnitDay,
+		"week": UnitWeek, "weeks": UnitWeek,
+		"month": UnitMonth, "months": UnitMonth,
+		"quarter": UnitQuarter, "quarters": UnitQuarter,
+		"year": UnitYear, "years": UnitYear,
+	},
+	Numbers: map[string]int{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10, "twelve": 12,
+	},
+}
+
+// NaturalRule extends the grammar of ParseNatural. It gets the lower-cased
+// words of a date, without a trailing time of day, and reports whether it
+// understood them.
+type NaturalRule func(words []string, reference time.Time) (NaturalDate, bool)
+
+// NaturalOptions configures ParseNatural
+type NaturalOptions struct {
+	// Locale holds the keywords to understand. Nil means EnglishLocale.
+	Locale *NaturalLocale
+	// WeekStart is the first day of the week for inputs like "next week"
+	WeekStart time.Weekday
+	// Rules are tried in order before the built-in grammar
+	Rules []NaturalRule
+}
+
+// Returns a copy of the locale with all keywords lower-cased, the way the words
+// of the input are matched against them
+func (l *NaturalLocale) lowerCased() *NaturalLocale {
+	lowered := *l
+	for _, keyword := range []*string{
+		&lowered.Now, &lowered.Today, &lowered.Tomorrow, &lowered.Yesterday,
+		&lowered.Next, &lowered.Last, &lowered.This, &lowered.In, &lowered.Ago, &lowered.At,
+		&lowered.FirstDay, &lowered.LastDay, &lowered.Noon, &lowered.Midnight, &lowered.AM, &lowered.PM,
+	} {
+		*keyword = strings.ToLower(*keyword)
+	}
+
+	lowered.Weekdays = make(map[string]time.Weekday, len(l.Weekdays))
+	for keyword, weekday := range l.Weekdays {
+		lowered.Weekdays[strings.ToLower(keyword)] = weekday
+	}
+	lowered.Units = make(map[string]Unit, len(l.Units))
+	for keyword, unit := range l.Units {
+		lowered.Units[strings.ToLower(keyword)] = unit
+	}
+	lowered.Numbers = make(map[string]int, len(l.Numbers))
+	for keyword, number := range l.Numbers {
+		lowered.Numbers[strings.ToLower(keyword)] = number
+	}
+	return &lowered
+}
+
+// Internal structure holding the state of a single ParseNatural call
+type naturalParser struct {
+	locale    *NaturalLocale
+	reference time.Time
+	opts      NaturalOptions
+}
+
+// ParseNatural resolves inputs like "next friday", "in 3 days", "tomorrow at 5pm",
+// "last day of next month" or "2 weeks ago" relative to the reference date, in
+// its location. The grammar is:
+//
+//	now | today | tomorrow | yesterday | 2006-01-02
+//	[next | last | this] <weekday>
+//	next | last | this <unit>
+//	in <number> <unit> | <number> <unit> ago
+//	first day of | last day of <date>
+//	<date> [at] <time> | <time>
+//
+// where a time is noon, midnight, 17:30, 5pm or 5:30 pm. Days, weeks and
+// longer units resolve to intervals, unless a time of day is given. A weekday
+// alone is the next one including today, "next" skips today and "this" keeps
+// to the current week.
+func ParseNatural(input string, reference time.Time, opts NaturalOptions) (NaturalDate, error) {
+	parser := naturalParser{locale: opts.Locale, reference: reference, opts: opts}
+	if parser.locale == nil {
+		parser.locale = EnglishLocale
+	}
+	parser.locale = parser.locale.lowerCased()
+
+	words := strings.Fields(strings.ToLower(input))
+	if date, ok := parser.parse(words); ok {
+		return date, nil
+	}
+	return NaturalDate{}, fmt.Errorf("%w: %q", ErrUnparsableNatural, input)
+}
+
+// Parses a date optionally followed by a time of day
+func (p naturalParser) parse(words []string) (NaturalDate, bool) {
+	if date, ok := p.parseDate(words); ok {
+		return date, true
+	}
+
+	for split := len(words) - 1; split >= 0; split-- {
+		sinceMidnight, ok := p.parseTimeOfDay(words[split:])
+		if !ok {
+			continue
+		}
+
+		dateWords := words[:split]
+		if end, ok := matchPhrase(dateWords, len(dateWords)-len(strings.Fields(p.locale.At)), p.locale.At); ok && end == len(dateWords) {
+			dateWords = dateWords[:len(dateWords)-len(strings.Fields(p.locale.At))]
+		}
+
+		day := p.dayRange(p.reference)
+		if len(dateWords) > 0 {
+			date, ok := p.parseDate(dateWords)
+			if !ok {
+				return NaturalDate{}, false
+			}
+			day = date
+		}
+
+		instant := atTimeOfDay(day.Start, sinceMidnight)
+		return NaturalDate{Start: instant, End: instant}, true
+	}
+	return NaturalDate{}, false
+}
+
+// Parses a date without a time of day
+func (p naturalParser) parseDate(words []string) (NaturalDate, bool) {
+	if len(words) == 0 {
+		return NaturalDate{}, false
+	}
+
+	for _, rule := range p.opts.Rules {
+		if date, ok := rule(words, p.reference); ok {
+			return date, true
+		}
+	}
+
+	locale := p.locale
+	switch {
+	case isPhrase(words, locale.Now):
+		return NaturalDate{Start: p.reference, End: p.reference}, true
+	case isPhrase(words, locale.Today):
+		return p.dayRange(p.reference), true
+	case isPhrase(words, locale.Tomorrow):
+		return p.dayRange(addUnits(p.reference, UnitDay, 1)), true
+	case isPhrase(words, locale.Yesterday):
+		return p.dayRange(addUnits(p.reference, UnitDay, -1)), true
+	}
+
+	if len(words) == 1 {
+		if date, err := time.ParseInLocation("2006-01-02", words[0], p.reference.Location()); err == nil {
+			return p.dayRange(date), true
+		}
+		if weekday, ok := locale.Weekdays[words[0]]; ok {
+			return p.dayRange(addUnits(p.reference, UnitDay, daysUntilWeekday(p.reference.Weekday(), weekday))), true
+		}
+	}
+
+	if end, ok := matchPhrase(words, 0, locale.In); ok {
+		if amount, unit, ok := p.parseAmount(words[end:]); ok {
+			instant := addUnits(p.reference, unit, amount)
+			return NaturalDate{Start: instant, End: instant}, true
+		}
+	}
+
+	if start := len(words) - len(strings.Fields(locale.Ago)); start > 0 {
+		if end, ok := matchPhrase(words, start, locale.Ago); ok && end == len(words) {
+			if amount, unit, ok := p.parseAmount(words[:start]); ok {
+				instant := addUnits(p.reference, unit, -amount)
+				return NaturalDate{Start: instant, End: instant}, true
+			}
+		}
+	}
+
+	for _, direction := range []struct {
+		keyword string
+		step    int
+	}{{locale.Next, 1}, {locale.Last, -1}, {locale.This, 0}} {
+		end, ok := matchPhrase(words, 0, direction.keyword)
+		if !ok || end != len(words)-1 {
+			continue
+		}
+
+		if weekday, ok := locale.Weekdays[words[end]]; ok {
+			return p.dayRange(p.relativeWeekday(weekday, direction.step)), true
+		}
+
+		if unit, ok := locale.Units[words[end]]; ok {
+			start := startOfUnit(p.reference, unit, p.opts.WeekStart)
+			r := bucketOf(addUnits(start, unit, direction.step), unit, p.opts.WeekStart)
+			return NaturalDate{Start: r.Start, End: r.End}, true
+		}
+	}
+
+	for _, edge := range []struct {
+		keyword string
+		last    bool
+	}{{locale.FirstDay, false}, {locale.LastDay, true}} {
+		end, ok := matchPhrase(words, 0, edge.keyword)
+		if !ok {
+			continue
+		}
+
+		period, ok := p.parseDate(words[end:])
+		if !ok || period.IsInstant() {
+			continue
+		}
+
+		if edge.last {
+			return p.dayRange(period.End.Add(-time.Nanosecond)), true
+		}
+		return p.dayRange(period.Start), true
+	}
+
+	return NaturalDate{}, false
+}
+
+// Parses a count followed by a unit, like "3 days" or "a week"
+func (p naturalParser) parseAmount(words []string) (int, Unit, bool) {
+	if len(words) != 2 {
+		return 0, 0, false
+	}
+
+	amount, ok := p.locale.Numbers[words[0]]
+	if !ok {
+		number, err := strconv.Atoi(words[0])
+		if err != nil || number < 0 {
+			return 0, 0, false
+		}
+		amount = number
+	}
+
+	unit, ok := p.locale.Units[words[1]]
+	return amount, unit, ok
+}
+
+// Parses a wall-clock time of day like noon, 17:30, 5pm or 5:30 pm
+func (p naturalParser) parseTimeOfDay(words []string) (time.Duration, bool) {
+	switch {
+	case isPhrase(words, p.locale.Noon):
+		return 12 * time.Hour, true
+	case isPhrase(words, p.locale.Midnight):
+		return 0, true
+	case len(words) == 0 || len(words) > 2:
+		return 0, false
+	}
+
+	text := strings.Join(words, "")
+	meridiem := ""
+	for _, suffix := range []string{p.locale.AM, p.locale.PM} {
+		if suffix != "" && strings.HasSuffix(text, suffix) {
+			meridiem, text = suffix, strings.TrimSuffix(text, suffix)
+		}
+	}
+
+	if len(words) == 2 && meridiem == "" {
+		return 0, false
+	}
+
+	var parts [3]int
+	fields := strings.Split(text, ":")
+	if len(fields) > 3 || (meridiem == "" && len(fields) < 2) {
+		return 0, false
+	}
+	for i, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil || (i > 0 && len(field) != 2) {
+			return 0, false
+		}
+		parts[i] = number
+	}
+
+	hour := parts[0]
+	if meridiem != "" {
+		if hour < 1 || hour > 12 {
+			return 0, false
+		}
+		hour %= 12
+		if meridiem == p.locale.PM {
+			hour += 12
+		}
+	}
+
+	if !IsValidTime(hour, parts[1], parts[2], 0) {
+		return 0, false
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(parts[1])*time.Minute + time.Duration(parts[2])*time.Second, true
+}
+
+// Returns the day of the weekday relative to the reference: the first one
+// after today going forward, the last one before today going back, or the
+// one in the current week
+func (p naturalParser) relativeWeekday(weekday time.Weekday, step int) time.Time {
+	switch step {
+	case 1:
+		return addUnits(p.reference, UnitDay, 1+daysUntilWeekday(addUnits(p.reference, UnitDay, 1).Weekday(), weekday))
+	case -1:
+		return addUnits(p.reference, UnitDay, -1-daysUntilWeekday(weekday, addUnits(p.reference, UnitDay, -1).Weekday()))
+	}
+	weekStart := StartOfWeekOn(p.reference, p.opts.WeekStart)
+	return addUnits(weekStart, UnitDay, daysUntilWeekday(p.opts.WeekStart, weekday))
+}
+
+// Returns the whole day of the date
+func (p naturalParser) dayRange(date time.Time) NaturalDate {
+	r := bucketOf(date, UnitDay, p.opts.WeekStart)
+	return NaturalDate{Start: r.Start, End: r.End}
+}
+
+// Returns how many days it is from one weekday forward to the other
+func daysUntilWeekday(from, to time.Weekday) int {
+	return (int(to) - int(from) + 7) % 7
+}
+
+// Reports whether the words start with the phrase at the index and returns the index after it
+func matchPhrase(words []string, index int, phrase string) (int, bool) {
+	phraseWords := strings.Fields(phrase)
+	if len(phraseWords) == 0 || index < 0 || index+len(phraseWords) > len(words) {
+		return index, false
+	}
+
+	for i, word := range phraseWords {
+		if words[index+i] != word {
+			return index, false
+		}
+	}
+	return index + len(phraseWords), true
+}
+
+// Reports whether the words are exactly the phrase
+func isPhrase(words []string, phrase string) bool {
+	end, ok := matchPhrase(words, 0, phrase)
+	return ok && end == len(words)
+}
@@ -0,0 +1,132 @@
+package thl
+
+import (
+	"time"
+)
+
+/************************
+ *** Rounding Helpers ***
+ ************************/
+
+// RoundingMode decides which of the two surrounding steps a date is rounded to
+type RoundingMode int
+
+const (
+	// RoundFloor rounds down to the step at or before the date
+	RoundFloor RoundingMode = iota
+	// RoundCeil rounds up to the step at or after the date
+	RoundCeil
+	// RoundHalfUp rounds to the nearest step, up when the date is halfway
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest step, to the even step when the date is halfway
+	RoundHalfEven
+)
+
+// Returns the unit whose start the steps of the unit are counted from.
+// Weeks and years have no parent and are counted from 0000-12-31, a Sunday,
+// and from year 0.
+func parentUnit(unit Unit) (Unit, bool) {
+	switch unit {
+	case UnitSecond:
+		return UnitMinute, true
+	case UnitMinute:
+		return UnitHour, true
+	case UnitHour:
+		return UnitDay, true
+	case UnitDay:
+		return UnitMonth, true
+	case UnitMonth, UnitQuarter:
+		return UnitYear, true
+	}
+	return unit, false
+}
+
+// Returns the number of whole units between the anchor and the wall-clock date
+func unitsSince(anchor, wall time.Time, unit Unit) int {
+	switch unit {
+	case UnitSecond:
+		return int(wall.Sub(anchor) / time.Second)
+	case UnitMinute:
+		return int(wall.Sub(anchor) / time.Minute)
+	case UnitHour:
+		return int(wall.Sub(anchor) / time.Hour)
+	case UnitDay:
+		return wall.Day() - 1
+	case UnitWeek:
+		return int((wall.Unix() - anchor.Unix()) / (7 * secondsInDay))
+	case UnitMonth:
+		return int(wall.Month()) - 1
+	case UnitQuarter:
+		return GetQuarter(wall) - 1
+	}
+	return wall.Year() - anchor.Year()
+}
+
+// RoundToNearest rounds the date to a multiple of step units, e.g. to the
+// nearest 15 minutes, 6 hours or 3 months. Steps are counted on the wall clock
+// from the start of the next larger unit: minutes from the start of the hour,
+// hours from midnight, days from the first of the month and months and
+// quarters from the start of the year. When the step does not divide that unit
+// the last step is cut short by its end, so rounding to 7 minutes never goes
+// past the full hour. Weeks start on Sunday, as with
+// StartOfWeekOn(date, time.Sunday).
+func RoundToNearest(date time.Time, unit Unit, step int, mode RoundingMode) (time.Time, error) {
+	if step < 1 {
+		return date, ErrInvalidStep
+	}
+
+	if mode < RoundFloor || mode > RoundHalfEven {
+		return date, ErrUnknownRoundingMode
+	}
+
+	if unit < UnitSecond || unit > UnitYear {
+		return date, ErrUnknownUnit
+	}
+
+	wall := InZoneKeepWallClock(date, time.UTC)
+
+	var anchor, limit time.Time
+	parent, hasParent := parentUnit(unit)
+	if hasParent {
+		anchor = startOfUnit(wall, parent, time.Monday)
+		limit = addUnits(anchor, parent, 1)
+	} else if unit == UnitWeek {
+		anchor = time.Date(0, time.December, 31, 0, 0, 0, 0, time.UTC)
+	} else {
+		anchor = time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	candidate := func(index int) time.Time {
+		stepStart := addUnits(anchor, unit, index*step)
+		if hasParent && stepStart.After(limit) {
+			return limit
+		}
+		return stepStart
+	}
+
+	index := unitsSince(anchor, wall, unit) / step
+	lower, upper := candidate(index), candidate(index+1)
+
+	rounded := lower
+	switch toLower, toUpper := wall.Sub(lower), upper.Sub(wall); mode {
+	case RoundCeil:
+		if toLower > 0 {
+			rounded = upper
+		}
+	case RoundHalfUp:
+		if toUpper <= toLower {
+			rounded = upper
+		}
+	case RoundHalfEven:
+		if toUpper < toLower || (toUpper == toLower && index%2 != 0) {
+			rounded = upper
+		}
+	}
+
+	// below an hour keep the offset of the date so the repeated hour after
+	// a daylight saving change rounds within itself
+	if unit == UnitSecond || unit == UnitMinute {
+		return date.Add(rounded.Sub(wall)), nil
+	}
+	return InZoneKeepWallClock(rounded, date.Location()), nil
+}
@@ -0,0 +1,213 @@
+package thl
+
+import (
+	"time"
+)
+
+/****************************
+ *** Working Hour Helpers ***
+ ****************************/
+
+// How many consecutive days without working hours are searched before giving up
+const maxDaysWithoutWorkingHours = 366
+
+// WorkingInterval is the half-open wall-clock range of a day the business is
+// open, as the time since midnight. End may be 24 hours to stay open until
+// midnight; intervals that end before they start are ignored.
+type WorkingInterval struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// WorkingSchedule describes when a business is open: the working intervals of
+// every weekday in its location, minus its holidays
+type WorkingSchedule struct {
+	// Location is the zone the working intervals are in. Nil means UTC.
+	Location *time.Location
+	// Week holds the working intervals indexed by time.Weekday
+	Week [7][]WorkingInterval
+	// IsHoliday reports whether the calendar day, passed as its midnight in
+	// Location, is closed all day. Nil means there are no holidays.
+	IsHoliday func(day time.Time) bool
+}
+
+// NewMonToFriSchedule creates a schedule open from the opening to the closing
+// time of day on the days IsMonToFri accepts
+func NewMonToFriSchedule(loc *time.Location, opening, closing time.Duration) WorkingSchedule {
+	schedule := WorkingSchedule{Location: loc}
+	for weekday := time.Monday; weekday <= time.Friday; weekday++ {
+		schedule.Week[weekday] = []WorkingInterval{{Start: opening, End: closing}}
+	}
+	return schedule
+}
+
+func (schedule WorkingSchedule) location() *time.Location {
+	if schedule.Location == nil {
+		return time.UTC
+	}
+	return schedule.Location
+}
+
+// Returns the wall-clock time of day on the calendar day in the location
+func atTimeOfDay(day time.Time, sinceMidnight time.Duration) time.Time {
+	return time.Date(day.Year(),
+		day.Month(),
+		day.Day(),
+		int(sinceMidnight/time.Hour),
+		int(sinceMidnight%time.Hour/time.Minute),
+		int(sinceMidnight%time.Minute/time.Second),
+		int(sinceMidnight%time.Second),
+		day.Location())
+}
+
+// Returns the ranges the business is open on the calendar day
+func (schedule WorkingSchedule) rangesOn(day time.Time) []TimeRange {
+	if schedule.IsHoliday != nil && schedule.IsHoliday(day) {
+		return nil
+	}
+
+	var ranges []TimeRange
+	for _, interval := range schedule.Week[day.Weekday()] {
+		start, end := interval.Start, interval.End
+		if start < 0 {
+			start = 0
+		}
+		if end > 24*time.Hour {
+			end = 24 * time.Hour
+		}
+		if end <= start {
+			continue
+		}
+		ranges = append(ranges, TimeRange{Start: atTimeOfDay(day, start), End: atTimeOfDay(day, end)})
+	}
+
+	SortBy(ranges, func(r TimeRange) time.Time { return r.Start }, ASC)
+	return ranges
+}
+
+// Returns the first opening range that ends after the date, merged with the
+// ranges that overlap or directly follow it, even across midnight
+func (schedule WorkingSchedule) nextRange(date time.Time) (TimeRange, bool) {
+	local := date.In(schedule.location())
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+
+	var found TimeRange
+	ok := false
+	for emptyDays := 0; emptyDays <= maxDaysWithoutWorkingHours; {
+		ranges := schedule.rangesOn(day)
+		if len(ranges) == 0 {
+			if ok {
+				return found, true
+			}
+			emptyDays++
+		}
+
+		for _, r := range ranges {
+			switch {
+			case !ok && r.End.After(date):
+				found, ok = r, true
+			case ok && !r.Start.After(found.End):
+				if r.End.After(found.End) {
+					found.End = r.End
+				}
+			case ok:
+				return found, true
+			}
+		}
+
+		// the merged range may only go on with a range starting at the next midnight
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, day.Location())
+		if ok && found.End.Before(day) {
+			return found, true
+		}
+	}
+	return found, ok
+}
+
+// IsWithinWorkingHours reports whether the business is open at the date
+func (schedule WorkingSchedule) IsWithinWorkingHours(date time.Time) bool {
+	r, ok := schedule.nextRange(date)
+	return ok && r.Contains(date)
+}
+
+// NextOpening returns the date when it is within working hours, otherwise the
+// instant the business opens next
+func (schedule WorkingSchedule) NextOpening(date time.Time) (time.Time, error) {
+	r, ok := schedule.nextRange(date)
+	if !ok {
+		return time.Time{}, ErrNoWorkingHours
+	}
+
+	if r.Start.Before(date) {
+		return date, nil
+	}
+	return r.Start, nil
+}
+
+// NextClosing returns the instant the business closes next after the date
+func (schedule WorkingSchedule) NextClosing(date time.Time) (time.Time, error) {
+	r, ok := schedule.nextRange(date)
+	if !ok {
+		return time.Time{}, ErrNoWorkingHours
+	}
+	return r.End, nil
+}
+
+// AddWorkingDuration returns the instant at which the duration of working
+// time has passed since the start, like the deadline of an SLA. Time outside
+// of working hours does not count.
+func (schedule WorkingSchedule) AddWorkingDuration(start time.Time, duration time.Duration) (time.Time, error) {
+	if duration < 0 {
+		return start, ErrNegativeDuration
+	}
+
+	current := start
+	for {
+		r, ok := schedule.nextRange(current)
+		if !ok {
+			return start, ErrNoWorkingHours
+		}
+
+		if r.Start.After(current) {
+			current = r.Start
+		}
+
+		available := r.End.Sub(current)
+		if duration <= available {
+			return current.Add(duration), nil
+		}
+
+		duration -= available
+		current = r.End
+	}
+}
+
+// WorkingDurationBetween returns the working time from the first date up to
+// the second one. It is negative when the second date is earlier.
+func (schedule WorkingSchedule) WorkingDurationBetween(dateLeft, dateRight time.Time) time.Duration {
+	if dateRight.Before(dateLeft) {
+		return -schedule.WorkingDurationBetween(dateRight, dateLeft)
+	}
+
+	var total time.Duration
+	current := dateLeft
+	for current.Before(dateRight) {
+		r, ok := schedule.nextRange(current)
+		if !ok || !r.Start.Before(dateRight) {
+			break
+		}
+
+		if r.Start.After(current) {
+			current = r.Start
+		}
+
+		end := r.End
+		if dateRight.Before(end) {
+			end = dateRight
+		}
+
+		total += end.Sub(current)
+		current = end
+	}
+	return total
+}
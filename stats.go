@@ -0,0 +1,181 @@
+package thl
+
+import (
+	"math"
+	"time"
+)
+
+/*************************
+ *** Statistic Helpers ***
+ *************************/
+
+// Mean returns the average instant of the dates in the location of the first one
+func Mean(datesSlice []time.Time) (time.Time, error) {
+	if err := validateSlice(datesSlice); err != nil {
+		return time.Time{}, err
+	}
+
+	// sum the offsets from the first date in whole seconds and nanoseconds, as
+	// a time.Duration only spans about 292 years
+	reference := datesSlice[0]
+	var seconds, nanos int64
+	for _, date := range datesSlice {
+		seconds += date.Unix() - reference.Unix()
+		nanos += int64(date.Nanosecond() - reference.Nanosecond())
+	}
+
+	count := int64(len(datesSlice))
+	meanSeconds := seconds / count
+	meanNanos := ((seconds%count)*int64(time.Second) + nanos) / count
+	// time.Unix normalises nanoseconds outside of a second into the seconds
+	mean := time.Unix(reference.Unix()+meanSeconds, int64(reference.Nanosecond())+meanNanos)
+	return mean.In(reference.Location()), nil
+}
+
+// Median returns the middle date, or the instant halfway between the two middle dates
+func Median(datesSlice []time.Time) (time.Time, error) {
+	return Percentile(datesSlice, 50)
+}
+
+// Percentile returns the date below which the passed percent of the dates fall,
+// interpolating linearly between the two closest dates
+func Percentile(datesSlice []time.Time, percent float64) (time.Time, error) {
+	if err := validateSlice(datesSlice); err != nil {
+		return time.Time{}, err
+	}
+
+	if percent < 0 || percent > 100 || math.IsNaN(percent) {
+		return time.Time{}, ErrPercentileOutOfRange
+	}
+
+	dates := sortedCopy(datesSlice)
+	rank := percent / 100 * float64(len(dates)-1)
+	lower := int(math.Floor(rank))
+	if lower == len(dates)-1 {
+		return dates[lower], nil
+	}
+
+	// interpolate on whole seconds and nanoseconds, as Sub saturates for
+	// dates more than about 292 years apart
+	fraction := rank - float64(lower)
+	seconds := fraction * float64(dates[lower+1].Unix()-dates[lower].Unix())
+	wholeSeconds := math.Floor(seconds)
+	nanos := (seconds-wholeSeconds)*float64(time.Second) +
+		fraction*float64(dates[lower+1].Nanosecond()-dates[lower].Nanosecond())
+
+	interpolated := time.Unix(dates[lower].Unix()+int64(wholeSeconds), int64(dates[lower].Nanosecond())+int64(math.Round(nanos)))
+	return interpolated.In(dates[lower].Location()), nil
+}
+
+// Span returns the time between the earliest and the latest date
+func Span(datesSlice []time.Time) (time.Duration, error) {
+	earliest, err := Min(datesSlice)
+	if err != nil {
+		return 0, err
+	}
+
+	latest, _ := Max(datesSlice)
+	return latest.Sub(earliest), nil
+}
+
+// ModeDay returns the start of the calendar day most of the dates fall on and
+// how many do. Ties go to the earliest day.
+func ModeDay(datesSlice []time.Time) (time.Time, int, error) {
+	if err := validateSlice(datesSlice); err != nil {
+		return time.Time{}, 0, err
+	}
+
+	var bestDay time.Time
+	bestCount := 0
+	dates := sortedCopy(datesSlice)
+	for start := 0; start < len(dates); {
+		end := start + 1
+		for end < len(dates) && IsSameDay(dates[start], dates[end]) {
+			end++
+		}
+
+		if end-start > bestCount {
+			bestDay, bestCount = StartOfDay(dates[start]), end-start
+		}
+		start = end
+	}
+	return bestDay, bestCount, nil
+}
+
+// ModeWeekday returns the weekday most of the dates fall on and how many do.
+// Ties go to the weekday coming first from Sunday.
+func ModeWeekday(datesSlice []time.Time) (time.Weekday, int, error) {
+	if err := validateSlice(datesSlice); err != nil {
+		return time.Sunday, 0, err
+	}
+
+	var counts [7]int
+	for _, date := range datesSlice {
+		counts[date.Weekday()]++
+	}
+
+	best := 0
+	for weekday := range counts {
+		if counts[weekday] > counts[best] {
+			best = weekday
+		}
+	}
+	return time.Weekday(best), counts[best], nil
+}
+
+// ModeHourOfDay returns the hour of the day most of the dates fall in and how
+// many do. Ties go to the earliest hour.
+func ModeHourOfDay(datesSlice []time.Time) (int, int, error) {
+	if err := validateSlice(datesSlice); err != nil {
+		return 0, 0, err
+	}
+
+	var counts [24]int
+	for _, date := range datesSlice {
+		counts[date.Hour()]++
+	}
+
+	best := 0
+	for hour := range counts {
+		if counts[hour] > counts[best] {
+			best = hour
+		}
+	}
+	return best, counts[best], nil
+}
+
+// CircularMeanTimeOfDay returns the mean wall-clock time of day of the dates
+// as time since midnight, rounded to the millisecond. The times of day are
+// averaged as points on a 24 hour clock face, so 23:00 and 01:00 average to
+// midnight instead of noon.
+func CircularMeanTimeOfDay(datesSlice []time.Time) (time.Duration, error) {
+	if err := validateSlice(datesSlice); err != nil {
+		return 0, err
+	}
+
+	day := float64(24 * time.Hour)
+	var x, y float64
+	for _, date := range datesSlice {
+		wall := InZoneKeepWallClock(date, time.UTC)
+		sinceMidnight := wall.Sub(StartOfDay(wall))
+		angle := 2 * math.Pi * float64(sinceMidnight) / day
+		x += math.Cos(angle)
+		y += math.Sin(angle)
+	}
+
+	count := float64(len(datesSlice))
+	if math.Hypot(x, y)/count < 1e-9 {
+		return 0, ErrNoCircularMean
+	}
+
+	angle := math.Atan2(y, x)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+
+	mean := time.Duration(angle / (2 * math.Pi) * day).Round(time.Millisecond)
+	if mean >= 24*time.Hour {
+		mean -= 24 * time.Hour
+	}
+	return mean, nil
+}
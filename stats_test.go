@@ -0,0 +1,38 @@
+package thl
+
+import (
+	"testing"
+	"time"
+)
+
+// A time.Duration only spans about 292 years, so these dates are too far
+// apart to be subtracted from each other
+func TestMeanBeyondDurationRange(t *testing.T) {
+	mean, err := Mean([]time.Time{pastDate, futureDate})
+	// 1001 to 3001 is 730485 days and 1001 to 2001 is 365243 of them
+	expected := time.Date(2000, 12, 31, 12, 0, 0, 0, time.UTC)
+	if err != nil || !mean.Equal(expected) {
+		t.Errorf("found %v %v, expected %v", mean, err, expected)
+	}
+
+	mean, _ = Mean([]time.Time{futureDate, pastDate, time.Date(2001, 1, 1, 0, 0, 0, 3, time.UTC)})
+	expected = time.Date(2000, 12, 31, 16, 0, 0, 1, time.UTC)
+	if !mean.Equal(expected) {
+		t.Errorf("found %v, expected %v", mean, expected)
+	}
+}
+
+func TestPercentileBeyondDurationRange(t *testing.T) {
+	yearOne := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	median, err := Median([]time.Time{futureDate, yearOne})
+	expected := time.Date(1501, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err != nil || !median.Equal(expected) {
+		t.Errorf("found %v %v, expected %v", median, err, expected)
+	}
+
+	quarter, _ := Percentile([]time.Time{pastDate, futureDate.Add(time.Nanosecond)}, 25)
+	expected = time.Date(1501, 1, 1, 6, 0, 0, 0, time.UTC)
+	if !quarter.Equal(expected) {
+		t.Errorf("found %v, expected %v", quarter, expected)
+	}
+}
@@ -0,0 +1,175 @@
+package thl
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+/*******************
+ *** Sun Helpers ***
+ *******************/
+
+// Zenith angles, in degrees, of the sun at the events of a day. Sunrise and
+// sunset account for the refraction of the atmosphere and the radius of the sun.
+const (
+	sunriseZenith      = 90.833
+	civilZenith        = 96.0
+	nauticalZenith     = 102.0
+	astronomicalZenith = 108.0
+)
+
+// Julian Date of the J2000.0 epoch the NOAA solar equations are based on
+const julianDateJ2000 = 2451545.0
+
+func degreesToRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func radiansToDegrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// Returns the declination of the sun in degrees and the equation of time in
+// minutes at the instant, following the NOAA solar calculator
+func solarPosition(date time.Time) (declination, equationOfTime float64) {
+	t := (ToJulianDate(date) - julianDateJ2000) / 36525
+
+	meanLongitude := math.Mod(280.46646+t*(36000.76983+t*0.0003032), 360)
+	meanAnomaly := 357.52911 + t*(35999.05029-0.0001537*t)
+	eccentricity := 0.016708634 - t*(0.000042037+0.0000001267*t)
+
+	anomaly := degreesToRadians(meanAnomaly)
+	equationOfCenter := math.Sin(anomaly)*(1.914602-t*(0.004817+0.000014*t)) +
+		math.Sin(2*anomaly)*(0.019993-0.000101*t) +
+		math.Sin(3*anomaly)*0.000289
+
+	omega := degreesToRadians(125.04 - 1934.136*t)
+	apparentLongitude := meanLongitude + equationOfCenter - 0.00569 - 0.00478*math.Sin(omega)
+	meanObliquity := 23 + (26+(21.448-t*(46.815+t*(0.00059-t*0.001813)))/60)/60
+	obliquity := degreesToRadians(meanObliquity + 0.00256*math.Cos(omega))
+
+	declination = radiansToDegrees(math.Asin(math.Sin(obliquity) * math.Sin(degreesToRadians(apparentLongitude))))
+
+	y := math.Pow(math.Tan(obliquity/2), 2)
+	longitude := degreesToRadians(meanLongitude)
+	equationOfTime = 4 * radiansToDegrees(y*math.Sin(2*longitude)-
+		2*eccentricity*math.Sin(anomaly)+
+		4*eccentricity*y*math.Sin(anomaly)*math.Cos(2*longitude)-
+		0.5*y*y*math.Sin(4*longitude)-
+		1.25*eccentricity*eccentricity*math.Sin(2*anomaly))
+	return declination, equationOfTime
+}
+
+// Returns the midnight in UTC of the day whose mean solar noon at the
+// longitude is closest to the noon of the calendar day of the date
+func solarDay(date time.Time, longitude float64) time.Time {
+	noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, date.Location())
+	meanSolarTime := noon.UTC().Add(time.Duration(longitude / 15 * float64(time.Hour)))
+	return time.Date(meanSolarTime.Year(), meanSolarTime.Month(), meanSolarTime.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Returns the solar noon of the solar day using the equation of time at the instant
+func solarNoonAt(day, at time.Time, longitude float64) time.Time {
+	_, equationOfTime := solarPosition(at)
+	minutes := 720 - 4*longitude - equationOfTime
+	return day.Add(time.Duration(minutes * float64(time.Minute)))
+}
+
+// Returns when the sun reaches the zenith angle before or after the solar
+// noon of the calendar day of the date, in the location of the date
+func sunEvent(date time.Time, latitude, longitude, zenith float64, afterNoon bool) (time.Time, error) {
+	day := solarDay(date, longitude)
+	event := solarNoonAt(day, day.Add(12*time.Hour), longitude)
+
+	// each pass computes the position of the sun closer to the event
+	for pass := 0; pass < 3; pass++ {
+		declination, _ := solarPosition(event)
+		lat, dec := degreesToRadians(latitude), degreesToRadians(declination)
+		cosHourAngle := math.Cos(degreesToRadians(zenith))/(math.Cos(lat)*math.Cos(dec)) - math.Tan(lat)*math.Tan(dec)
+
+		if cosHourAngle > 1 {
+			return time.Time{}, ErrSunAlwaysDown
+		}
+		if cosHourAngle < -1 {
+			return time.Time{}, ErrSunAlwaysUp
+		}
+
+		offset := time.Duration(4 * radiansToDegrees(math.Acos(cosHourAngle)) * float64(time.Minute))
+		if !afterNoon {
+			offset = -offset
+		}
+		event = solarNoonAt(day, event, longitude).Add(offset)
+	}
+	return event.Round(time.Second).In(date.Location()), nil
+}
+
+// SolarNoon returns when the sun is highest on the calendar day of the date at
+// the latitude and longitude in degrees, east and north being positive
+func SolarNoon(date time.Time, latitude, longitude float64) time.Time {
+	day := solarDay(date, longitude)
+	noon := solarNoonAt(day, day.Add(12*time.Hour), longitude)
+	return solarNoonAt(day, noon, longitude).Round(time.Second).In(date.Location())
+}
+
+// Sunrise returns when the upper edge of the sun rises above the horizon on
+// the calendar day of the date. During polar day and night it returns
+// ErrSunAlwaysUp and ErrSunAlwaysDown instead.
+func Sunrise(date time.Time, latitude, longitude float64) (time.Time, error) {
+	return sunEvent(date, latitude, longitude, sunriseZenith, false)
+}
+
+// Sunset returns when the upper edge of the sun sets below the horizon on
+// the calendar day of the date. During polar day and night it returns
+// ErrSunAlwaysUp and ErrSunAlwaysDown instead.
+func Sunset(date time.Time, latitude, longitude float64) (time.Time, error) {
+	return sunEvent(date, latitude, longitude, sunriseZenith, true)
+}
+
+// CivilDawn returns when the sun rises to 6 degrees below the horizon
+func CivilDawn(date time.Time, latitude, longitude float64) (time.Time, error) {
+	return sunEvent(date, latitude, longitude, civilZenith, false)
+}
+
+// CivilDusk returns when the sun sets to 6 degrees below the horizon
+func CivilDusk(date time.Time, latitude, longitude float64) (time.Time, error) {
+	return sunEvent(date, latitude, longitude, civilZenith, true)
+}
+
+// NauticalDawn returns when the sun rises to 12 degrees below the horizon
+func NauticalDawn(date time.Time, latitude, longitude float64) (time.Time, error) {
+	return sunEvent(date, latitude, longitude, nauticalZenith, false)
+}
+
+// NauticalDusk returns when the sun sets to 12 degrees below the horizon
+func NauticalDusk(date time.Time, latitude, longitude float64) (time.Time, error) {
+	return sunEvent(date, latitude, longitude, nauticalZenith, true)
+}
+
+// AstronomicalDawn returns when the sun rises to 18 degrees below the horizon
+func AstronomicalDawn(date time.Time, latitude, longitude float64) (time.Time, error) {
+	return sunEvent(date, latitude, longitude, astronomicalZenith, false)
+}
+
+// AstronomicalDusk returns when the sun sets to 18 degrees below the horizon
+func AstronomicalDusk(date time.Time, latitude, longitude float64) (time.Time, error) {
+	return sunEvent(date, latitude, longitude, astronomicalZenith, true)
+}
+
+// DayLength returns the time between sunrise and sunset on the calendar day
+// of the date, 24 hours during polar day and 0 during polar night
+func DayLength(date time.Time, latitude, longitude float64) time.Duration {
+	sunrise, err := Sunrise(date, latitude, longitude)
+	if errors.Is(err, ErrSunAlwaysUp) {
+		return 24 * time.Hour
+	}
+	if err != nil {
+		return 0
+	}
+
+	sunset, err := Sunset(date, latitude, longitude)
+	if err != nil {
+		return 0
+	}
+	return sunset.Sub(sunrise)
+}
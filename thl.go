@@ -3,7 +3,7 @@
 package thl
 
 import (
-	"errors"
+	"fmt"
 	"math"
 	"sort"
 	"time"
@@ -13,6 +13,11 @@ import (
  *** General Helpers ***
  ***********************/
 
+// Key function for using the generic helpers on slices of dates
+func identity(date time.Time) time.Time {
+	return date
+}
+
 // Internal structure used for sorting slices of dates
 type timeSort []time.Time
 
@@ -57,6 +62,28 @@ func SortDesc(timeSlice []time.Time) {
 	Sort(timeSlice, DESC)
 }
 
+// SortBy stably sorts a slice of any type by the date the key function returns.
+// Elements with equal dates are ordered by the tie-break comparators in turn,
+// each returning -1, 0 or 1 like Compare, and keep their order otherwise.
+func SortBy[T any](slice []T, key func(T) time.Time, comparator Constant, tieBreaks ...func(a, b T) int) {
+	if ASC != comparator && DESC != comparator {
+		return
+	}
+
+	sort.SliceStable(slice, func(i, j int) bool {
+		if order := Compare(key(slice[i]), key(slice[j])) * int(comparator); order != 0 {
+			return order < 0
+		}
+
+		for _, tieBreak := range tieBreaks {
+			if order := tieBreak(slice[i], slice[j]); order != 0 {
+				return order < 0
+			}
+		}
+		return false
+	})
+}
+
 // Compare two dates and return:
 // -1 if the first date is before the second date
 // 0 if they are the same date
@@ -73,13 +100,20 @@ func Compare(first time.Time, second time.Time) int {
 
 // Finds index of the date from the slice that is closest to the date passed
 func ClosestIndexTo(dateToCompare time.Time, datesSlice []time.Time) (int, error) {
+	return ClosestIndexBy(dateToCompare, datesSlice, identity)
+}
 
-	if datesSlice == nil {
-		return 0, errors.New("Passed slice of dates was nil")
-	}
+// Finds the date from the slice that is closest to the date passed
+func ClosestTo(dateToCompare time.Time, datesSlice []time.Time) (time.Time, error) {
+	return ClosestBy(dateToCompare, datesSlice, identity)
+}
+
+// Finds index of the element from the slice whose date is closest to the date passed.
+// The first of equally close elements wins.
+func ClosestIndexBy[T any](dateToCompare time.Time, slice []T, key func(T) time.Time) (int, error) {
 
-	if len(datesSlice) == 0 {
-		return 0, errors.New("Passed slice of dates was of size 0")
+	if err := validateSlice(slice); err != nil {
+		return 0, err
 	}
 
 	var closestIndex int
@@ -88,7 +122,8 @@ func ClosestIndexTo(dateToCompare time.Time, datesSlice []time.Time) (int, error
 
 	dateMiliUnix := dateToCompare.Unix()
 	dateNanoUnix := dateToCompare.UnixNano()
-	for index, date := range datesSlice {
+	for index, element := range slice {
+		date := key(element)
 		unixDiffMili := dateMiliUnix - date.Unix()
 		unixDiffNano := dateNanoUnix - date.UnixNano()
 
@@ -116,15 +151,16 @@ func ClosestIndexTo(dateToCompare time.Time, datesSlice []time.Time) (int, error
 	return closestIndex, nil
 }
 
-// Finds the date from the slice that is closest to the date passed
-func ClosestTo(dateToCompare time.Time, datesSlice []time.Time) (time.Time, error) {
-	index, err := ClosestIndexTo(dateToCompare, datesSlice)
+// Finds the element from the slice whose date is closest to the date passed
+func ClosestBy[T any](dateToCompare time.Time, slice []T, key func(T) time.Time) (T, error) {
+	index, err := ClosestIndexBy(dateToCompare, slice, key)
 
 	if err != nil {
-		return time.Time{}, err
+		var zero T
+		return zero, err
 	}
 
-	return datesSlice[index], nil
+	return slice[index], nil
 }
 
 // Checks if the date is in the future
@@ -139,44 +175,42 @@ func IsPast(dateToTest time.Time) bool {
 
 // Finds the latest date chronologically
 func Max(datesSlice []time.Time) (time.Time, error) {
-	if datesSlice == nil {
-		return time.Time{}, errors.New("Passed slice of dates was nil")
-	}
-
-	if len(datesSlice) == 0 {
-		return time.Time{}, errors.New("Passed slice of dates was of size 0")
-	}
-
-	dateToReturn := datesSlice[0]
-
-	for _, testDate := range datesSlice {
-		if testDate.After(dateToReturn) {
-			dateToReturn = testDate
-		}
-	}
-
-	return dateToReturn, nil
+	return MaxBy(datesSlice, identity)
 }
 
 // Finds the latest date reverse chronologically
 func Min(datesSlice []time.Time) (time.Time, error) {
-	if datesSlice == nil {
-		return time.Time{}, errors.New("Passed slice of dates was nil")
-	}
+	return MinBy(datesSlice, identity)
+}
 
-	if len(datesSlice) == 0 {
-		return time.Time{}, errors.New("Passed slice of dates was of size 0")
+// Finds the element with the latest date, the first one on ties
+func MaxBy[T any](slice []T, key func(T) time.Time) (T, error) {
+	return extremeBy(slice, key, time.Time.After)
+}
+
+// Finds the element with the earliest date, the first one on ties
+func MinBy[T any](slice []T, key func(T) time.Time) (T, error) {
+	return extremeBy(slice, key, time.Time.Before)
+}
+
+// Returns the first element no other element is better than
+func extremeBy[T any](slice []T, key func(T) time.Time, better func(time.Time, time.Time) bool) (T, error) {
+	var toReturn T
+	if err := validateSlice(slice); err != nil {
+		return toReturn, err
 	}
 
-	dateToReturn := datesSlice[0]
+	toReturn = slice[0]
+	dateToReturn := key(toReturn)
 
-	for _, testDate := range datesSlice {
-		if testDate.Before(dateToReturn) {
+	for _, element := range slice {
+		if testDate := key(element); better(testDate, dateToReturn) {
+			toReturn = element
 			dateToReturn = testDate
 		}
 	}
 
-	return dateToReturn, nil
+	return toReturn, nil
 }
 
 // Cheks if the ranges overlap
@@ -199,7 +233,7 @@ func GetOverlappingDaysInRanges(
 	areOverlapping := AreRangesOverlapping(initialRangeStartDate, initialRangeEndDate, endRangeStartDate, endRangeEndDate)
 
 	if !areOverlapping {
-		return 0, errors.New("Ranges do not overlap")
+		return 0, ErrRangesNotOverlapping
 	}
 
 	return -1 * DifferenceInDays(endRangeStartDate, initialRangeEndDate), nil
@@ -229,8 +263,8 @@ func GetMilliseconds(date time.Time) int {
 }
 
 func SetMillisecond(date time.Time, amount int) (time.Time, error) {
-	if amount < 0 || amount > 999 {
-		return date, errors.New("Passed amount was less than 0 or more than 999. Date left unchanged.")
+	if err := checkRange("millisecond", amount, 0, 999); err != nil {
+		return date, err
 	}
 	return time.Date(date.Year(),
 		date.Month(),
@@ -281,8 +315,8 @@ func IsThisSecond(date time.Time) bool {
 }
 
 func SetSeconds(date time.Time, seconds int) (time.Time, error) {
-	if seconds < 0 || seconds > 59 {
-		return date, errors.New("Passed amount was less than 0 or more than 59. Date left unchanged.")
+	if err := checkRange("second", seconds, 0, 59); err != nil {
+		return date, err
 	}
 	return time.Date(date.Year(),
 		date.Month(),
@@ -329,8 +363,8 @@ func IsThisMinute(date time.Time) bool {
 
 func SetMinutes(date time.Time, minutes int) (time.Time, error) {
 
-	if minutes < 0 || minutes > 59 {
-		return date, errors.New("Passed amount was less than 0 or more than 59. Date left unchanged.")
+	if err := checkRange("minute", minutes, 0, 59); err != nil {
+		return date, err
 	}
 
 	return time.Date(date.Year(),
@@ -375,8 +409,8 @@ func IsThisHour(date time.Time) bool {
 }
 
 func SetHours(date time.Time, hours int) (time.Time, error) {
-	if hours < 0 || hours > 23 {
-		return date, errors.New("Passed amount was less than 0 or more than 23. Date left unchanged.")
+	if err := checkRange("hour", hours, 0, 23); err != nil {
+		return date, err
 	}
 
 	return time.Date(
@@ -437,7 +471,7 @@ func EachDay(startDate, endDate time.Time) ([]time.Time, error) {
 	var datesRange []time.Time
 
 	if endDate.Before(startDate) {
-		return datesRange, errors.New("End date can not be before start date. Returned empty slice.")
+		return datesRange, fmt.Errorf("%w. Returned empty slice.", ErrEndBeforeStart)
 	}
 
 	counterDate := AddDays(startDate, 1)
@@ -498,17 +532,19 @@ func SetDayOfYear(date time.Time, dayNumber int) (time.Time, error) {
 		daysInYear++
 	}
 
-	if dayNumber < 0 || dayNumber > daysInYear {
-		return date, errors.New("Given day number if out of range. Returned unchanged date.")
+	if err := checkRange("day of year", dayNumber, 0, daysInYear); err != nil {
+		return date, err
 	}
 
 	return AddDays(FirstDayOfYear(date), dayNumber), nil
 }
 
+// SetDayOfMonth sets the day of the month. Days outside of the month are a
+// RangeError, including days below 1.
 func SetDayOfMonth(date time.Time, dayMonthNumber int) (time.Time, error) {
 	daysInMonth := GetDaysInMonth(date)
-	if dayMonthNumber > daysInMonth {
-		return time.Time{}, errors.New("Passed days count is bigger than the days count in the month of the passed date")
+	if err := checkRange("day of month", dayMonthNumber, 1, daysInMonth); err != nil {
+		return time.Time{}, err
 	}
 	return time.Date(date.Year(),
 		date.Month(),
@@ -555,6 +591,17 @@ func StartOfWeek(date time.Time) time.Time {
 	return StartOfDay(AddDays(EndOfWeek(date), -7))
 }
 
+// Returns the start of the week containing the date for weeks starting on the passed weekday
+func StartOfWeekOn(date time.Time, weekStart time.Weekday) time.Time {
+	daysBack := (int(date.Weekday()) - int(weekStart) + 7) % 7
+	return time.Date(date.Year(), date.Month(), date.Day()-daysBack, 0, 0, 0, 0, date.Location())
+}
+
+// Returns the end of the week containing the date for weeks starting on the passed weekday
+func EndOfWeekOn(date time.Time, weekStart time.Weekday) time.Time {
+	return EndOfDay(StartOfWeekOn(date, weekStart).AddDate(0, 0, 6))
+}
+
 func IsSameWeek(dateOne, dateTwo time.Time) bool {
 	weekOne := EndOfWeek(dateOne)
 	weekTwo := EndOfWeek(dateTwo)
@@ -654,72 +701,123 @@ func AddQuarters(date time.Time, amount int) time.Time {
 	return AddMonths(date, amount*3)
 }
 
+// Returns the first month of the quarter, quarters being numbered from 1 to 4
+func firstMonthOfQuarter(quarter int) time.Month {
+	return time.Month((quarter-1)*3 + 1)
+}
+
+func GetQuarter(date time.Time) int {
+	return (int(date.Month())-1)/3 + 1
+}
+
 func IsFirstQuarter(date time.Time) bool {
-	return time.January == date.Month() || time.February == date.Month() || time.March == date.Month()
+	return GetQuarter(date) == 1
 }
 
 func IsSecondQuarter(date time.Time) bool {
-	return time.April == date.Month() || time.May == date.Month() || time.June == date.Month()
+	return GetQuarter(date) == 2
 }
 
 func IsThirdQuarter(date time.Time) bool {
-	return time.July == date.Month() || time.August == date.Month() || time.September == date.Month()
+	return GetQuarter(date) == 3
 }
 
 func IsFourthQuarter(date time.Time) bool {
-	return time.October == date.Month() || time.November == date.Month() || time.December == date.Month()
+	return GetQuarter(date) == 4
+}
+
+func StartOfQuarter(date time.Time) time.Time {
+	return time.Date(date.Year(), firstMonthOfQuarter(GetQuarter(date)), 1, 0, 0, 0, 0, date.Location())
+}
+
+// Returns the start of the last day of the quarter
+func LastDayOfQuarter(date time.Time) time.Time {
+	// day 0 of the first month of the next quarter is the last day of this one
+	return time.Date(date.Year(), firstMonthOfQuarter(GetQuarter(date)+1), 0, 0, 0, 0, 0, date.Location())
 }
 
 func EndOfQuarter(date time.Time) time.Time {
-	if IsFirstQuarter(date) {
-		return time.Date(date.Year(), time.March, 31, 23, 59, 59, 999999999, date.Location())
-	}
+	return EndOfDay(LastDayOfQuarter(date))
+}
 
-	if IsSecondQuarter(date) {
-		return time.Date(date.Year(), time.June, 31, 23, 59, 59, 999999999, date.Location())
+func GetDaysInQuarter(date time.Time) int {
+	return LastDayOfQuarter(date).YearDay() - StartOfQuarter(date).YearDay() + 1
+}
+
+// Moves the date to the same month of the passed quarter. The day is clamped
+// to the last day of the target month.
+func SetQuarter(date time.Time, quarter int) (time.Time, error) {
+	if err := checkRange("quarter", quarter, 1, 4); err != nil {
+		return date, err
 	}
 
-	if IsFirstQuarter(date) {
-		return time.Date(date.Year(), time.September, 31, 23, 59, 59, 999999999, date.Location())
+	month := firstMonthOfQuarter(quarter) + time.Month((int(date.Month())-1)%3)
+	day := date.Day()
+	if daysInMonth := GetDaysInMonth(time.Date(date.Year(), month, 1, 0, 0, 0, 0, date.Location())); day > daysInMonth {
+		day = daysInMonth
 	}
 
-	return time.Date(date.Year(), time.December, 31, 23, 59, 59, 999999999, date.Location())
+	return time.Date(date.Year(),
+		month,
+		day,
+		date.Hour(),
+		date.Minute(),
+		date.Second(),
+		date.Nanosecond(),
+		date.Location()), nil
+}
+
+// Returns the number of calendar quarters between the dates, ignoring the day and time
+func DifferenceInCalendarQuarters(endDate, startDate time.Time) int {
+	return endDate.Year()*4 + GetQuarter(endDate) - (startDate.Year()*4 + GetQuarter(startDate))
 }
 
-func StartOfQuarter(date time.Time) time.Time {
-	if IsFirstQuarter(date) {
-		return time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, date.Location())
-	}
+// Returns the number of full quarters between the dates
+func DifferenceInQuarters(endDate, startDate time.Time) int {
+	return differenceInFullMonths(endDate, startDate) / 3
+}
 
-	if IsSecondQuarter(date) {
-		return time.Date(date.Year(), time.April, 1, 0, 0, 0, 0, date.Location())
-	}
+// Returns the number of full months between the dates by comparing their
+// wall-clock values. From the last day of a month to the last day of another
+// is a full month however long the months are, e.g. June 30 to March 31.
+func differenceInFullMonths(endDate, startDate time.Time) int {
+	months := (endDate.Year()-startDate.Year())*12 + int(endDate.Month()) - int(startDate.Month())
 
-	if IsFirstQuarter(date) {
-		return time.Date(date.Year(), time.July, 1, 0, 0, 0, 0, date.Location())
+	// compare what is left after the month: the day and the time of day
+	endDay, startDay := endDate.Day(), startDate.Day()
+	if IsLastDayOfMonth(endDate) && IsLastDayOfMonth(startDate) {
+		endDay, startDay = 1, 1
 	}
+	endRest := time.Date(2000, time.January, endDay, endDate.Hour(), endDate.Minute(), endDate.Second(), endDate.Nanosecond(), time.UTC)
+	startRest := time.Date(2000, time.January, startDay, startDate.Hour(), startDate.Minute(), startDate.Second(), startDate.Nanosecond(), time.UTC)
 
-	return time.Date(date.Year(), time.October, 1, 0, 0, 0, 0, date.Location())
+	if months > 0 && endRest.Before(startRest) {
+		months--
+	} else if months < 0 && endRest.After(startRest) {
+		months++
+	}
+	return months
 }
 
-func GetQuarter(date time.Time) int {
-	if IsFirstQuarter(date) {
-		return 1
-	}
-	if IsSecondQuarter(date) {
-		return 2
+// Returns the start of every quarter from the quarter of the start date up to
+// and including the quarter of the end date
+func EachQuarterOfInterval(startDate, endDate time.Time) ([]time.Time, error) {
+	var quarters []time.Time
+
+	if endDate.Before(startDate) {
+		return quarters, fmt.Errorf("%w. Returned empty slice.", ErrEndBeforeStart)
 	}
-	if IsFirstQuarter(date) {
-		return 3
+
+	last := StartOfQuarter(endDate)
+	for current := StartOfQuarter(startDate); !current.After(last); current = AddQuarters(current, 1) {
+		quarters = append(quarters, current)
 	}
-	return 4
+
+	return quarters, nil
 }
 
 func IsSameQuarter(dateOne, dateTwo time.Time) bool {
-	if dateOne.Year() != dateTwo.Year() {
-		return false
-	}
-	return EndOfQuarter(dateOne) == EndOfQuarter(dateTwo)
+	return dateOne.Year() == dateTwo.Year() && GetQuarter(dateOne) == GetQuarter(dateTwo)
 }
 
 func IsThisQuarter(date time.Time) bool {
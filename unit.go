@@ -0,0 +1,94 @@
+package thl
+
+import (
+	"time"
+)
+
+/********************
+ *** Unit Helpers ***
+ ********************/
+
+// Unit is a unit of time, from seconds up to calendar years
+type Unit int
+
+const (
+	UnitSecond Unit = iota
+	UnitMinute
+	UnitHour
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitQuarter
+	UnitYear
+)
+
+// UnitUnknown is not a unit, functions return it alongside an error when they
+// could not tell the unit
+const UnitUnknown Unit = -1
+
+func (unit Unit) String() string {
+	switch unit {
+	case UnitSecond:
+		return "second"
+	case UnitMinute:
+		return "minute"
+	case UnitHour:
+		return "hour"
+	case UnitDay:
+		return "day"
+	case UnitWeek:
+		return "week"
+	case UnitMonth:
+		return "month"
+	case UnitQuarter:
+		return "quarter"
+	case UnitYear:
+		return "year"
+	}
+	return "unknown"
+}
+
+// Returns the start of the unit containing the date. Units up to an hour are
+// measured back from the date itself, so a date in a repeated hour after a
+// daylight saving change stays in its own hour.
+func startOfUnit(date time.Time, unit Unit, weekStart time.Weekday) time.Time {
+	elapsed := time.Duration(date.Nanosecond())
+	switch unit {
+	case UnitSecond:
+		return date.Add(-elapsed)
+	case UnitMinute:
+		return date.Add(-elapsed - time.Duration(date.Second())*time.Second)
+	case UnitHour:
+		return date.Add(-elapsed - time.Duration(date.Second())*time.Second - time.Duration(date.Minute())*time.Minute)
+	case UnitDay:
+		return StartOfDay(date)
+	case UnitWeek:
+		return StartOfWeekOn(date, weekStart)
+	case UnitMonth:
+		return StartOfMonth(date)
+	case UnitQuarter:
+		return StartOfQuarter(date)
+	}
+	return StartOfYear(date)
+}
+
+// Moves the date by the amount of units. Calendar units keep the wall-clock time.
+func addUnits(date time.Time, unit Unit, amount int) time.Time {
+	switch unit {
+	case UnitSecond:
+		return date.Add(time.Duration(amount) * time.Second)
+	case UnitMinute:
+		return date.Add(time.Duration(amount) * time.Minute)
+	case UnitHour:
+		return date.Add(time.Duration(amount) * time.Hour)
+	case UnitDay:
+		return date.AddDate(0, 0, amount)
+	case UnitWeek:
+		return date.AddDate(0, 0, 7*amount)
+	case UnitMonth:
+		return date.AddDate(0, amount, 0)
+	case UnitQuarter:
+		return date.AddDate(0, 3*amount, 0)
+	}
+	return date.AddDate(amount, 0, 0)
+}
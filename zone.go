@@ -0,0 +1,113 @@
+package thl
+
+import (
+	"strings"
+	"time"
+)
+
+/************************
+ *** Timezone Helpers ***
+ ************************/
+
+// InZoneKeepWallClock returns a date in the passed location that shows the same
+// wall-clock time as the passed date. Unlike date.In(loc) the instant changes.
+func InZoneKeepWallClock(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(),
+		date.Month(),
+		date.Day(),
+		date.Hour(),
+		date.Minute(),
+		date.Second(),
+		date.Nanosecond(),
+		loc)
+}
+
+// ZoneOffsetAt returns the UTC offset of the location at the passed instant
+func ZoneOffsetAt(loc *time.Location, date time.Time) time.Duration {
+	_, offset := date.In(loc).Zone()
+	return time.Duration(offset) * time.Second
+}
+
+// OffsetDifference returns how far ahead the first location is of the second
+// one at the passed instant. A negative value means it is behind.
+func OffsetDifference(locA, locB *time.Location, at time.Time) time.Duration {
+	return ZoneOffsetAt(locA, at) - ZoneOffsetAt(locB, at)
+}
+
+// ZonedTime pairs an instant with the IANA name of the zone it belongs to.
+// Its text form is an RFC 3339 timestamp followed by the zone name in square
+// brackets, e.g. 2017-03-12T09:00:00-04:00[America/New_York], so the zone
+// survives a round trip through JSON or any other text encoding.
+type ZonedTime struct {
+	date time.Time
+}
+
+// NewZonedTime creates a zoned time for the passed instant in the location
+func NewZonedTime(date time.Time, loc *time.Location) ZonedTime {
+	return ZonedTime{date: date.In(loc)}
+}
+
+// LoadZonedTime creates a zoned time for the passed instant in the zone with the given IANA name
+func LoadZonedTime(date time.Time, zoneName string) (ZonedTime, error) {
+	loc, err := time.LoadLocation(zoneName)
+	if err != nil {
+		return ZonedTime{}, err
+	}
+	return NewZonedTime(date, loc), nil
+}
+
+// Time returns the instant expressed in the zone
+func (zt ZonedTime) Time() time.Time {
+	return zt.date
+}
+
+// Location returns the zone of the zoned time
+func (zt ZonedTime) Location() *time.Location {
+	return zt.date.Location()
+}
+
+// ZoneName returns the IANA name of the zone
+func (zt ZonedTime) ZoneName() string {
+	return zt.date.Location().String()
+}
+
+// IsZero reports whether the zoned time holds the zero instant
+func (zt ZonedTime) IsZero() bool {
+	return zt.date.IsZero()
+}
+
+// Equal reports whether both zoned times hold the same instant in the same zone
+func (zt ZonedTime) Equal(other ZonedTime) bool {
+	return zt.date.Equal(other.date) && zt.ZoneName() == other.ZoneName()
+}
+
+func (zt ZonedTime) String() string {
+	return zt.date.Format(time.RFC3339Nano) + "[" + zt.ZoneName() + "]"
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (zt ZonedTime) MarshalText() ([]byte, error) {
+	return []byte(zt.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (zt *ZonedTime) UnmarshalText(data []byte) error {
+	text := string(data)
+	open := strings.LastIndexByte(text, '[')
+	if open < 0 || !strings.HasSuffix(text, "]") {
+		return ErrMalformedZonedTime
+	}
+
+	date, err := time.Parse(time.RFC3339Nano, text[:open])
+	if err != nil {
+		return err
+	}
+
+	parsed, err := LoadZonedTime(date, text[open+1:len(text)-1])
+	if err != nil {
+		return err
+	}
+
+	*zt = parsed
+	return nil
+}